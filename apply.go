@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+func newApplyCmd() *cobra.Command {
+	var (
+		file   string
+		dryRun bool
+		yes    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <file>",
+		Short: "Apply a YAML or JSON changeset of puts and deletes",
+		Long: `Apply a YAML or JSON list of changes:
+
+  - key: /config/app/mode
+    value: blue
+    expect_revision: 1234   # optional; 0 means the key must not exist
+  - op: delete
+    key: /config/app/legacy
+
+op defaults to put, so a file exported from the TUI is a valid changeset.
+A diff against the cluster is printed for every key and you are asked to
+confirm before the changes are committed, in transactions of at most 128
+operations. If a key no longer has its expected revision nothing is written.
+
+With --dry-run only the diff is printed. The command exits non-zero when a
+key has drifted from its expected revision.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(file, dryRun, yes, os.Stdin, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Changeset file to apply")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the diff without writing anything")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Apply without asking for confirmation")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runApply(file string, dryRun, yes bool, in io.Reader, out io.Writer) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	entries, err := etcd.ParseChangeset(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	repo, err := connect()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	plan := repo.PlanChangeset(entries)().(etcd.ChangesetPlanMsg)
+	if plan.Err != nil {
+		return plan.Err
+	}
+
+	var puts, deletes, unchanged, drifted int
+	for _, item := range plan.Items {
+		printChangesetItem(out, item)
+		switch {
+		case item.Drifted():
+			drifted++
+		case item.Noop():
+			unchanged++
+		case item.Op == etcd.TxnDelete:
+			deletes++
+		default:
+			puts++
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d to put, %d to delete, %d unchanged\n", puts, deletes, unchanged)
+	if drifted > 0 {
+		return fmt.Errorf("%d keys drifted from their expected revision: %w", drifted, etcd.ErrRevisionConflict)
+	}
+	if dryRun {
+		return nil
+	}
+	if puts+deletes == 0 {
+		fmt.Fprintln(out, "Nothing to apply")
+		return nil
+	}
+
+	if !yes && !confirmApply(in, out, puts+deletes) {
+		return fmt.Errorf("aborted")
+	}
+
+	result := repo.ApplyChangeset(plan.Items)().(etcd.ChangesetMsg)
+	fmt.Fprintf(out, "Applied %d changes\n", result.Applied)
+	return result.Err
+}
+
+func printChangesetItem(out io.Writer, item etcd.ChangesetItem) {
+	after := item.Value
+	if item.Op == etcd.TxnDelete {
+		after = ""
+	}
+
+	switch {
+	case item.Drifted():
+		found := fmt.Sprintf("found %d", item.ModRevision)
+		if !item.Exists {
+			found = "key does not exist"
+		}
+		fmt.Fprintf(out, "! %s: expected revision %d, %s\n", item.Key, *item.ExpectRevision, found)
+	case item.Noop() && item.Op == etcd.TxnDelete:
+		fmt.Fprintf(out, "= %s (already absent)\n", item.Key)
+		return
+	case item.Noop():
+		fmt.Fprintf(out, "= %s (unchanged)\n", item.Key)
+		return
+	case !item.Exists:
+		fmt.Fprintf(out, "+ %s (new)\n", item.Key)
+	case item.Op == etcd.TxnDelete:
+		fmt.Fprintf(out, "- %s (delete, revision %d)\n", item.Key, item.ModRevision)
+	default:
+		fmt.Fprintf(out, "~ %s (revision %d)\n", item.Key, item.ModRevision)
+	}
+
+	for _, line := range utils.UnifiedDiff(item.Current, after, 3) {
+		fmt.Fprintf(out, "    %s\n", line)
+	}
+}
+
+func confirmApply(in io.Reader, out io.Writer, changes int) bool {
+	fmt.Fprintf(out, "Apply %d changes? [y/N] ", changes)
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+)
+
+func newCloneCmd() *cobra.Command {
+	var onConflict string
+
+	cmd := &cobra.Command{
+		Use:   "clone <source-prefix> <destination-prefix>",
+		Short: "Copy every key under a prefix to a new prefix",
+		Long: `Copy every key under a source prefix to the same suffix under a destination prefix.
+
+Keys that already exist under the destination are listed first. Use
+--on-conflict to skip or overwrite them, or to abort; without the flag you
+are asked interactively.`,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClone(args[0], args[1], onConflict, os.Stdin, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&onConflict, "on-conflict", "", "What to do with existing destination keys: skip, overwrite or abort")
+
+	return cmd
+}
+
+func runClone(src, dst, onConflict string, in io.Reader, out io.Writer) error {
+	mode := etcd.CollisionAbort
+	if onConflict != "" {
+		var err error
+		if mode, err = etcd.ParseCollisionMode(onConflict); err != nil {
+			return err
+		}
+	}
+
+	repo, err := connect()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	plan := repo.PlanClone(src, dst)().(etcd.ClonePlanMsg)
+	if plan.Err != nil {
+		return plan.Err
+	}
+	if plan.Count == 0 {
+		fmt.Fprintf(out, "No keys under %s\n", src)
+		return nil
+	}
+
+	fmt.Fprintf(out, "%d keys under %s\n", plan.Count, src)
+	if len(plan.Collisions) > 0 {
+		fmt.Fprintf(out, "%d keys already exist under %s:\n", len(plan.Collisions), dst)
+		for _, key := range plan.Collisions {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+
+		if onConflict == "" {
+			if mode, err = promptCollisionMode(in, out); err != nil {
+				return err
+			}
+		}
+		if mode == etcd.CollisionAbort {
+			return fmt.Errorf("aborted: %d destination keys already exist", len(plan.Collisions))
+		}
+	}
+
+	result := repo.ClonePrefix(src, dst, mode)().(etcd.CloneMsg)
+	fmt.Fprintf(out, "Copied %d keys to %s (%d skipped, %d overwritten)\n",
+		len(result.Copied), dst, result.Skipped, result.Overwritten)
+	return result.Err
+}
+
+func promptCollisionMode(in io.Reader, out io.Writer) (etcd.CollisionMode, error) {
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprint(out, "[s]kip, [o]verwrite or [a]bort? ")
+		line, err := reader.ReadString('\n')
+		if mode, parseErr := etcd.ParseCollisionMode(strings.TrimSpace(line)); parseErr == nil {
+			return mode, nil
+		}
+		if err != nil {
+			return etcd.CollisionAbort, fmt.Errorf("no answer given: %w", err)
+		}
+	}
+}
+
+// connect opens a repository for the one-shot subcommands using the same
+// configuration as the TUI.
+func connect() (etcd.Repository, error) {
+	repo := etcd.NewRepository()
+	msg := repo.Connect().(etcd.ConnectionMsg)
+	if !msg.Success {
+		return nil, msg.Err
+	}
+	return repo, nil
+}
@@ -15,6 +15,7 @@ type Config struct {
 	Cert      string `json:"cert"`
 	Username  string `json:"username"`
 	Password  string `json:"password"`
+	ReadOnly  bool   `json:"read_only"`
 }
 
 const configDir = ".etcd-tui"
@@ -23,6 +24,12 @@ const configFile = "config.json"
 var (
 	customConfigPath string
 	configPathMutex  sync.RWMutex
+
+	readOnlyOverride bool
+	readOnlyMutex    sync.RWMutex
+
+	revision      int64
+	revisionMutex sync.RWMutex
 )
 
 func SetConfigPath(path string) {
@@ -31,6 +38,20 @@ func SetConfigPath(path string) {
 	customConfigPath = path
 }
 
+// SetReadOnly forces read-only mode regardless of the config file.
+func SetReadOnly(readOnly bool) {
+	readOnlyMutex.Lock()
+	defer readOnlyMutex.Unlock()
+	readOnlyOverride = readOnly
+}
+
+// SetRevision pins reads to a past revision; 0 reads the latest one.
+func SetRevision(rev int64) {
+	revisionMutex.Lock()
+	defer revisionMutex.Unlock()
+	revision = rev
+}
+
 func getConfigPath() (string, error) {
 	configPathMutex.RLock()
 	customPath := customConfigPath
@@ -139,3 +160,21 @@ func GetPassword() string {
 	}
 	return os.Getenv("ETCDCTL_PASSWORD")
 }
+
+func GetReadOnly() bool {
+	readOnlyMutex.RLock()
+	override := readOnlyOverride
+	readOnlyMutex.RUnlock()
+
+	if override {
+		return true
+	}
+	cfg, _ := Load()
+	return cfg != nil && cfg.ReadOnly
+}
+
+func GetRevision() int64 {
+	revisionMutex.RLock()
+	defer revisionMutex.RUnlock()
+	return revision
+}
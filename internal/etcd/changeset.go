@@ -0,0 +1,209 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ChangesetEntry is one put or delete of an apply file. ExpectRevision, when
+// set, is the mod revision the key must still have; 0 means it must not
+// exist.
+type ChangesetEntry struct {
+	Op             TxnOpType
+	Key            string
+	Value          string
+	ExpectRevision *int64
+}
+
+// changesetEntry is the file form of an entry. Op defaults to put, so an
+// export file is also a valid changeset.
+type changesetEntry struct {
+	Op             string  `json:"op" yaml:"op"`
+	Key            string  `json:"key" yaml:"key"`
+	Value          *string `json:"value" yaml:"value"`
+	ExpectRevision *int64  `json:"expect_revision" yaml:"expect_revision"`
+}
+
+// ParseChangeset reads a YAML or JSON list of changes. Unknown fields and
+// keys listed twice are rejected.
+func ParseChangeset(data []byte) ([]ChangesetEntry, error) {
+	var raw []changesetEntry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("changeset is empty")
+	}
+
+	entries := make([]ChangesetEntry, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for i, r := range raw {
+		if r.Key == "" {
+			return nil, fmt.Errorf("entry %d: key is required", i+1)
+		}
+		if seen[r.Key] {
+			return nil, fmt.Errorf("entry %d: %s is listed more than once", i+1, r.Key)
+		}
+		seen[r.Key] = true
+
+		entry := ChangesetEntry{Key: r.Key, ExpectRevision: r.ExpectRevision}
+		switch strings.ToLower(r.Op) {
+		case "", string(TxnPut):
+			if r.Value == nil {
+				return nil, fmt.Errorf("entry %d (%s): put needs a value", i+1, r.Key)
+			}
+			entry.Op, entry.Value = TxnPut, *r.Value
+		case string(TxnDelete):
+			if r.Value != nil {
+				return nil, fmt.Errorf("entry %d (%s): delete takes no value", i+1, r.Key)
+			}
+			entry.Op = TxnDelete
+		default:
+			return nil, fmt.Errorf("entry %d (%s): unknown op %q (want put or delete)", i+1, r.Key, r.Op)
+		}
+		if r.ExpectRevision != nil && *r.ExpectRevision < 0 {
+			return nil, fmt.Errorf("entry %d (%s): expect_revision must not be negative", i+1, r.Key)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ChangesetItem is an entry next to the key's current state in the cluster.
+type ChangesetItem struct {
+	ChangesetEntry
+	Exists      bool
+	Current     string
+	ModRevision int64
+}
+
+// Drifted reports whether the key no longer has the revision the entry
+// expects.
+func (it ChangesetItem) Drifted() bool {
+	return it.ExpectRevision != nil && *it.ExpectRevision != it.ModRevision
+}
+
+// Noop reports whether applying the entry would leave the key as it is.
+func (it ChangesetItem) Noop() bool {
+	if it.Op == TxnDelete {
+		return !it.Exists
+	}
+	return it.Exists && it.Current == it.Value
+}
+
+// PlanChangeset reads the current raw value and mod revision of every key in
+// the changeset.
+func (r *repository) PlanChangeset(entries []ChangesetEntry) tea.Cmd {
+	return func() tea.Msg {
+		msg := ChangesetPlanMsg{}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+
+		msg.Items = make([]ChangesetItem, 0, len(entries))
+		for batch := range slices.Chunk(entries, MaxTxnOps) {
+			ops := make([]clientv3.Op, 0, len(batch))
+			for _, entry := range batch {
+				ops = append(ops, clientv3.OpGet(entry.Key))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			resp, err := r.client().Txn(ctx).Then(ops...).Commit()
+			cancel()
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+
+			for i, op := range resp.Responses {
+				item := ChangesetItem{ChangesetEntry: batch[i]}
+				if kvs := op.GetResponseRange().Kvs; len(kvs) > 0 {
+					item.Exists = true
+					item.Current = string(kvs[0].Value)
+					item.ModRevision = kvs[0].ModRevision
+				}
+				msg.Items = append(msg.Items, item)
+			}
+		}
+		return msg
+	}
+}
+
+// ApplyChangeset writes the planned items that change something, in
+// transactions of at most MaxTxnOps operations. Each transaction only
+// commits if its keys still have the revisions seen by the plan.
+func (r *repository) ApplyChangeset(items []ChangesetItem) tea.Cmd {
+	return func() tea.Msg {
+		msg := ChangesetMsg{}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+		if err := r.checkWritable(); err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		pending := slices.DeleteFunc(slices.Clone(items), ChangesetItem.Noop)
+		for batch := range slices.Chunk(pending, MaxTxnOps) {
+			cmps := make([]clientv3.Cmp, 0, len(batch))
+			ops := make([]clientv3.Op, 0, len(batch))
+			for _, it := range batch {
+				cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(it.Key), "=", it.ModRevision))
+				if it.Op == TxnDelete {
+					ops = append(ops, clientv3.OpDelete(it.Key, clientv3.WithPrevKV()))
+				} else {
+					ops = append(ops, clientv3.OpPut(it.Key, it.Value, clientv3.WithPrevKV()))
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			resp, err := r.client().Txn(ctx).If(cmps...).Then(ops...).Commit()
+			cancel()
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			if !resp.Succeeded {
+				msg.Err = fmt.Errorf("a key changed after the preview: %w", ErrRevisionConflict)
+				return msg
+			}
+
+			for i, op := range resp.Responses {
+				if dr := op.GetResponseDeleteRange(); dr != nil {
+					msg.Changes = append(msg.Changes, deleteChanges(dr.PrevKvs)...)
+					continue
+				}
+				msg.Changes = append(msg.Changes, Change{
+					Key:      batch[i].Key,
+					Prev:     op.GetResponsePut().GetPrevKv(),
+					Revision: resp.Header.Revision,
+				})
+			}
+			msg.Applied += len(batch)
+		}
+		return msg
+	}
+}
@@ -0,0 +1,128 @@
+package etcd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChangeset(t *testing.T) {
+	rev := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []ChangesetEntry
+		wantErr  bool
+	}{
+		{
+			name: "yaml",
+			input: `
+- key: /a
+  value: "1"
+  expect_revision: 12
+- op: delete
+  key: /b
+- op: PUT
+  key: /c
+  value: |
+    line one
+    line two
+  expect_revision: 0
+`,
+			expected: []ChangesetEntry{
+				{Op: TxnPut, Key: "/a", Value: "1", ExpectRevision: rev(12)},
+				{Op: TxnDelete, Key: "/b"},
+				{Op: TxnPut, Key: "/c", Value: "line one\nline two\n", ExpectRevision: rev(0)},
+			},
+		},
+		{
+			name:  "export file",
+			input: "[\n\t{\n\t\t\"key\": \"/a\",\n\t\t\"value\": \"{\\\"x\\\": 1}\"\n\t}\n]\n",
+			expected: []ChangesetEntry{
+				{Op: TxnPut, Key: "/a", Value: `{"x": 1}`},
+			},
+		},
+		{
+			name:     "empty value",
+			input:    `[{"key": "/a", "value": ""}]`,
+			expected: []ChangesetEntry{{Op: TxnPut, Key: "/a"}},
+		},
+		{name: "empty file", input: "\n", wantErr: true},
+		{name: "not a list", input: "key: /a\nvalue: b\n", wantErr: true},
+		{name: "unknown field", input: "- key: /a\n  val: b\n", wantErr: true},
+		{name: "unknown json field", input: `[{"key": "/a", "val": "b"}]`, wantErr: true},
+		{name: "missing key", input: "- value: b\n", wantErr: true},
+		{name: "put without value", input: "- key: /a\n", wantErr: true},
+		{name: "delete with value", input: "- op: delete\n  key: /a\n  value: b\n", wantErr: true},
+		{name: "unknown op", input: "- op: get\n  key: /a\n", wantErr: true},
+		{name: "negative revision", input: "- op: delete\n  key: /a\n  expect_revision: -1\n", wantErr: true},
+		{name: "duplicate key", input: "- key: /a\n  value: b\n- op: delete\n  key: /a\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseChangeset([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseChangeset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseChangeset() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChangesetItem(t *testing.T) {
+	rev := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name    string
+		item    ChangesetItem
+		drifted bool
+		noop    bool
+	}{
+		{
+			name: "new key",
+			item: ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnPut, Key: "/a", Value: "1"}},
+		},
+		{
+			name: "new key expected absent",
+			item: ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnPut, Key: "/a", Value: "1", ExpectRevision: rev(0)}},
+		},
+		{
+			name:    "created by someone else",
+			item:    ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnPut, Key: "/a", Value: "1", ExpectRevision: rev(0)}, Exists: true, Current: "2", ModRevision: 9},
+			drifted: true,
+		},
+		{
+			name: "same value",
+			item: ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnPut, Key: "/a", Value: "1"}, Exists: true, Current: "1", ModRevision: 9},
+			noop: true,
+		},
+		{
+			name:    "changed since written",
+			item:    ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnPut, Key: "/a", Value: "1", ExpectRevision: rev(8)}, Exists: true, Current: "0", ModRevision: 9},
+			drifted: true,
+		},
+		{
+			name: "delete missing key",
+			item: ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnDelete, Key: "/a"}},
+			noop: true,
+		},
+		{
+			name: "delete at expected revision",
+			item: ChangesetItem{ChangesetEntry: ChangesetEntry{Op: TxnDelete, Key: "/a", ExpectRevision: rev(9)}, Exists: true, Current: "1", ModRevision: 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.Drifted(); got != tt.drifted {
+				t.Errorf("Drifted() = %v, want %v", got, tt.drifted)
+			}
+			if got := tt.item.Noop(); got != tt.noop {
+				t.Errorf("Noop() = %v, want %v", got, tt.noop)
+			}
+		})
+	}
+}
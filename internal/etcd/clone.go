@@ -0,0 +1,178 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// CollisionMode decides what a clone does with destination keys that
+// already exist.
+type CollisionMode int
+
+const (
+	CollisionAbort CollisionMode = iota
+	CollisionSkip
+	CollisionOverwrite
+)
+
+func (c CollisionMode) String() string {
+	switch c {
+	case CollisionSkip:
+		return "skip"
+	case CollisionOverwrite:
+		return "overwrite"
+	default:
+		return "abort"
+	}
+}
+
+func ParseCollisionMode(s string) (CollisionMode, error) {
+	switch strings.ToLower(s) {
+	case "abort", "a":
+		return CollisionAbort, nil
+	case "skip", "s":
+		return CollisionSkip, nil
+	case "overwrite", "o":
+		return CollisionOverwrite, nil
+	}
+	return CollisionAbort, fmt.Errorf("unknown collision mode %q (want skip, overwrite or abort)", s)
+}
+
+const clonePageSize = MaxTxnOps
+
+// PlanClone counts the keys under src and lists the ones whose destination
+// under dst already exists. Only keys are read.
+func (r *repository) PlanClone(src, dst string) tea.Cmd {
+	return func() tea.Msg {
+		plan := ClonePlanMsg{Source: src, Dest: dst}
+		if r.client() == nil {
+			plan.Err = fmt.Errorf("etcd client not initialized")
+			return plan
+		}
+		if src == dst {
+			plan.Err = fmt.Errorf("source and destination are the same")
+			return plan
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		plan.Err = r.forEachPage(ctx, src, 500, true, func(kvs []*mvccpb.KeyValue) error {
+			plan.Count += len(kvs)
+			existing, err := r.existingClones(ctx, kvs, src, dst)
+			if err != nil {
+				return err
+			}
+			for _, kv := range kvs {
+				if target := cloneTarget(string(kv.Key), src, dst); existing[target] {
+					plan.Collisions = append(plan.Collisions, target)
+				}
+			}
+			return nil
+		})
+		return plan
+	}
+}
+
+// ClonePrefix copies every key under src to the same suffix under dst, one
+// transaction per page. Leases are not copied.
+func (r *repository) ClonePrefix(src, dst string, mode CollisionMode) tea.Cmd {
+	return func() tea.Msg {
+		msg := CloneMsg{Source: src, Dest: dst}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+		if src == dst {
+			msg.Err = fmt.Errorf("source and destination are the same")
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		msg.Err = r.forEachPage(ctx, src, clonePageSize, false, func(kvs []*mvccpb.KeyValue) error {
+			existing, err := r.existingClones(ctx, kvs, src, dst)
+			if err != nil {
+				return err
+			}
+
+			var cmps []clientv3.Cmp
+			ops := make([]clientv3.Op, 0, len(kvs))
+
+			for _, kv := range kvs {
+				target := cloneTarget(string(kv.Key), src, dst)
+				if existing[target] {
+					switch mode {
+					case CollisionSkip:
+						msg.Skipped++
+						continue
+					case CollisionAbort:
+						return fmt.Errorf("%s: %w", target, ErrKeyExists)
+					}
+				} else if mode != CollisionOverwrite {
+					cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(target), "=", 0))
+				}
+				ops = append(ops, clientv3.OpPut(target, string(kv.Value), clientv3.WithPrevKV()))
+			}
+
+			if len(ops) == 0 {
+				return nil
+			}
+
+			resp, err := r.client().Txn(ctx).If(cmps...).Then(ops...).Commit()
+			if err != nil {
+				return err
+			}
+			if !resp.Succeeded {
+				return fmt.Errorf("a destination key was created during the clone: %w", ErrKeyExists)
+			}
+			msg.Revisions = append(msg.Revisions, resp.Header.Revision)
+
+			// A key that existed when the put ran was overwritten, whether or
+			// not it was there when the page was checked.
+			for i, op := range resp.Responses {
+				prev := op.GetResponsePut().GetPrevKv()
+				if prev != nil {
+					msg.Overwritten++
+				}
+				msg.Copied = append(msg.Copied, putKeyValue(ops[i].KeyBytes(), ops[i].ValueBytes(), resp.Header.Revision, 0, prev))
+				msg.Changes = append(msg.Changes, Change{
+					Key:      string(ops[i].KeyBytes()),
+					Prev:     prev,
+					Revision: resp.Header.Revision,
+				})
+			}
+			return nil
+		})
+		return msg
+	}
+}
+
+// existingClones returns which destination keys for a page of source keys
+// already exist. The mapping keeps key order, so one range covers the page.
+func (r *repository) existingClones(ctx context.Context, kvs []*mvccpb.KeyValue, src, dst string) (map[string]bool, error) {
+	first := cloneTarget(string(kvs[0].Key), src, dst)
+	last := cloneTarget(string(kvs[len(kvs)-1].Key), src, dst)
+
+	resp, err := r.client().Get(ctx, first, clientv3.WithRange(last+"\x00"), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		existing[string(kv.Key)] = true
+	}
+	return existing, nil
+}
+
+func cloneTarget(key, src, dst string) string {
+	return dst + strings.TrimPrefix(key, src)
+}
@@ -0,0 +1,51 @@
+package etcd
+
+import "testing"
+
+func TestParseCollisionMode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected CollisionMode
+		wantErr  bool
+	}{
+		{"skip", CollisionSkip, false},
+		{"S", CollisionSkip, false},
+		{"overwrite", CollisionOverwrite, false},
+		{"o", CollisionOverwrite, false},
+		{"Abort", CollisionAbort, false},
+		{"", CollisionAbort, true},
+		{"replace", CollisionAbort, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseCollisionMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCollisionMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseCollisionMode(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCloneTarget(t *testing.T) {
+	tests := []struct {
+		key, src, dst string
+		expected      string
+	}{
+		{"/config/v1/a", "/config/v1/", "/config/v2/", "/config/v2/a"},
+		{"/config/v1/a/b", "/config/v1/", "/staging/", "/staging/a/b"},
+		{"/config/v1", "/config/v1", "/config/v2", "/config/v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			result := cloneTarget(tt.key, tt.src, tt.dst)
+			if result != tt.expected {
+				t.Errorf("cloneTarget(%q, %q, %q) = %q, want %q", tt.key, tt.src, tt.dst, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// CompareKeys loads two keys to show side by side. The right key is read at
+// the revision the left one was read at, so that both come from the same
+// snapshot.
+func (r *repository) CompareKeys(left, right string) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return CompareMsg{Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		msg := CompareMsg{Revision: r.Revision()}
+		for _, side := range []struct {
+			key string
+			kv  *KeyValue
+		}{{left, &msg.Left}, {right, &msg.Right}} {
+			var opts []clientv3.OpOption
+			if msg.Revision > 0 {
+				opts = append(opts, clientv3.WithRev(msg.Revision))
+			}
+			resp, err := r.client().Get(ctx, side.key, opts...)
+			if err != nil {
+				return CompareMsg{Err: err}
+			}
+			if len(resp.Kvs) == 0 {
+				return CompareMsg{Err: fmt.Errorf("%s: %w", side.key, ErrKeyNotFound)}
+			}
+			*side.kv = kvFromProto(resp.Kvs[0])
+			if msg.Revision == 0 {
+				msg.Revision = resp.Header.Revision
+			}
+		}
+		return msg
+	}
+}
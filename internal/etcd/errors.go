@@ -0,0 +1,96 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// ErrRevisionConflict is returned when a guarded write finds that the key was
+// modified after the caller loaded it.
+var ErrRevisionConflict = errors.New("key was modified by someone else")
+
+// ErrReadOnly is returned by every write while read-only mode is enabled.
+var ErrReadOnly = errors.New("etcd-tui is in read-only mode")
+
+// ErrKeyExists is returned when a write would overwrite an existing key.
+var ErrKeyExists = errors.New("key already exists")
+
+// ErrKeyNotFound is returned when the key to operate on does not exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+// ErrLeaseExpired is returned when a key cannot be restored because the lease
+// it was attached to no longer exists.
+var ErrLeaseExpired = errors.New("lease has expired")
+
+// ErrHistorical is returned by every write while reads are pinned to a past
+// revision.
+var ErrHistorical = errors.New("viewing a past revision: writes are disabled")
+
+// CompactedError is returned when a pinned revision is older than the
+// cluster's compact revision. CompactRevision is the oldest revision that
+// can still be read, or 0 when it could not be looked up.
+type CompactedError struct {
+	Revision        int64
+	CompactRevision int64
+}
+
+func (e *CompactedError) Error() string {
+	if e.CompactRevision == 0 {
+		return fmt.Sprintf("revision %d has been compacted", e.Revision)
+	}
+	return fmt.Sprintf("revision %d has been compacted; the oldest readable revision is %d", e.Revision, e.CompactRevision)
+}
+
+func (e *CompactedError) Unwrap() error {
+	return rpctypes.ErrCompacted
+}
+
+// Recovery is an action that may get past an error.
+type Recovery int
+
+const (
+	RecoverNone Recovery = iota
+	// RecoverReconnect replaces the client, which also re-authenticates.
+	RecoverReconnect
+	// RecoverNextEndpoint reconnects through the next configured endpoint.
+	RecoverNextEndpoint
+	// RecoverCompactRevision pins reads to the oldest revision still readable.
+	RecoverCompactRevision
+)
+
+// Explain turns the errors etcd commonly returns into a hint the user can act
+// on, together with the recovery worth offering. The hint is empty for
+// errors it does not know.
+func Explain(err error) (string, Recovery) {
+	var compacted *CompactedError
+	switch {
+	case err == nil:
+		return "", RecoverNone
+	case errors.As(err, &compacted) && compacted.CompactRevision > 0:
+		return "history before the compact revision is gone", RecoverCompactRevision
+	case errors.Is(err, rpctypes.ErrCompacted):
+		return "history before the compact revision is gone; pick a newer revision", RecoverNone
+	case errors.Is(err, rpctypes.ErrPermissionDenied), errors.Is(err, rpctypes.ErrPermissionNotGranted):
+		return "the user has no role granting access to this key range", RecoverNone
+	case errors.Is(err, rpctypes.ErrInvalidAuthToken), errors.Is(err, rpctypes.ErrAuthOldRevision):
+		return "the auth token has expired", RecoverReconnect
+	case errors.Is(err, rpctypes.ErrAuthFailed):
+		return "check the username and password", RecoverNone
+	case errors.Is(err, rpctypes.ErrNoLeader):
+		return "this member has no leader; the cluster may have lost quorum", RecoverNextEndpoint
+	case errors.Is(err, rpctypes.ErrTimeout),
+		errors.Is(err, rpctypes.ErrTimeoutDueToLeaderFail),
+		errors.Is(err, rpctypes.ErrTimeoutDueToConnectionLost),
+		errors.Is(err, rpctypes.ErrTimeoutWaitAppliedIndex),
+		errors.Is(err, context.DeadlineExceeded):
+		return "the endpoint did not answer in time", RecoverNextEndpoint
+	case errors.Is(err, rpctypes.ErrRequestTooLarge):
+		return "the request exceeds the server's --max-request-bytes; write smaller values or fewer keys at once", RecoverNone
+	case errors.Is(err, rpctypes.ErrNoSpace):
+		return "the storage quota is exhausted; compact, defragment and disarm the NOSPACE alarm", RecoverNone
+	}
+	return "", RecoverNone
+}
@@ -0,0 +1,46 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		hint     bool
+		recovery Recovery
+	}{
+		{"nil", nil, false, RecoverNone},
+		{"unknown", errors.New("boom"), false, RecoverNone},
+		{"compacted", rpctypes.ErrCompacted, true, RecoverNone},
+		{"compacted with revision", &CompactedError{Revision: 3, CompactRevision: 10}, true, RecoverCompactRevision},
+		{"compacted without revision", &CompactedError{Revision: 3}, true, RecoverNone},
+		{"permission denied", rpctypes.ErrPermissionDenied, true, RecoverNone},
+		{"wrapped permission denied", fmt.Errorf("failed to fetch keys: %w", rpctypes.ErrPermissionDenied), true, RecoverNone},
+		{"token expired", rpctypes.ErrInvalidAuthToken, true, RecoverReconnect},
+		{"auth old revision", rpctypes.ErrAuthOldRevision, true, RecoverReconnect},
+		{"no leader", rpctypes.ErrNoLeader, true, RecoverNextEndpoint},
+		{"timeout", rpctypes.ErrTimeout, true, RecoverNextEndpoint},
+		{"deadline", fmt.Errorf("failed to connect to etcd: %w", context.DeadlineExceeded), true, RecoverNextEndpoint},
+		{"too large", rpctypes.ErrRequestTooLarge, true, RecoverNone},
+		{"no space", rpctypes.ErrNoSpace, true, RecoverNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint, recovery := Explain(tt.err)
+			if (hint != "") != tt.hint {
+				t.Errorf("Explain(%v) hint = %q, want hint %v", tt.err, hint, tt.hint)
+			}
+			if recovery != tt.recovery {
+				t.Errorf("Explain(%v) recovery = %v, want %v", tt.err, recovery, tt.recovery)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// ExportEntry is one key in an export file.
+type ExportEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ExportKeys reads the raw values of keys and writes them to path as a JSON
+// array of key/value objects. Keys that no longer exist are left out.
+func (r *repository) ExportKeys(keys []string, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := ExportMsg{Path: path}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+
+		entries := make([]ExportEntry, 0, len(keys))
+		for batch := range slices.Chunk(keys, MaxTxnOps) {
+			ops := make([]clientv3.Op, 0, len(batch))
+			for _, key := range batch {
+				ops = append(ops, clientv3.OpGet(key, clientv3.WithRev(r.Revision())))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			resp, err := r.client().Txn(ctx).Then(ops...).Commit()
+			cancel()
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+
+			for _, op := range resp.Responses {
+				for _, kv := range op.GetResponseRange().Kvs {
+					entries = append(entries, ExportEntry{Key: string(kv.Key), Value: string(kv.Value)})
+				}
+			}
+		}
+
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		msg.Count = len(entries)
+		return msg
+	}
+}
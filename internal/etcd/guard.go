@@ -0,0 +1,106 @@
+package etcd
+
+import (
+	"context"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// guardedKV wraps the client's KV so that every write, including writes
+// inside transactions, is checked against the repository's write policy, and
+// every Get reads at the pinned revision unless it asks for another one.
+// Installing it on the client means no repository method can bypass it.
+type guardedKV struct {
+	clientv3.KV
+	r *repository
+}
+
+func (kv *guardedKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	opts = append([]clientv3.OpOption{clientv3.WithRev(kv.r.Revision())}, opts...)
+	resp, err := kv.KV.Get(ctx, key, opts...)
+	if err != nil {
+		return nil, kv.r.revisionErr(ctx, clientv3.OpGet(key, opts...).Rev(), err)
+	}
+	return resp, nil
+}
+
+func (kv *guardedKV) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	if err := kv.r.checkWritable(); err != nil {
+		return nil, err
+	}
+	return kv.KV.Put(ctx, key, val, opts...)
+}
+
+func (kv *guardedKV) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	if err := kv.r.checkWritable(); err != nil {
+		return nil, err
+	}
+	return kv.KV.Delete(ctx, key, opts...)
+}
+
+func (kv *guardedKV) Compact(ctx context.Context, rev int64, opts ...clientv3.CompactOption) (*clientv3.CompactResponse, error) {
+	if err := kv.r.checkWritable(); err != nil {
+		return nil, err
+	}
+	return kv.KV.Compact(ctx, rev, opts...)
+}
+
+func (kv *guardedKV) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	if isWrite(op) {
+		if err := kv.r.checkWritable(); err != nil {
+			return clientv3.OpResponse{}, err
+		}
+	}
+	return kv.KV.Do(ctx, op)
+}
+
+func (kv *guardedKV) Txn(ctx context.Context) clientv3.Txn {
+	return &guardedTxn{Txn: kv.KV.Txn(ctx), r: kv.r}
+}
+
+type guardedTxn struct {
+	clientv3.Txn
+	r      *repository
+	writes bool
+}
+
+func (t *guardedTxn) If(cs ...clientv3.Cmp) clientv3.Txn {
+	t.Txn = t.Txn.If(cs...)
+	return t
+}
+
+func (t *guardedTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.writes = t.writes || anyWrite(ops)
+	t.Txn = t.Txn.Then(ops...)
+	return t
+}
+
+func (t *guardedTxn) Else(ops ...clientv3.Op) clientv3.Txn {
+	t.writes = t.writes || anyWrite(ops)
+	t.Txn = t.Txn.Else(ops...)
+	return t
+}
+
+func (t *guardedTxn) Commit() (*clientv3.TxnResponse, error) {
+	if t.writes {
+		if err := t.r.checkWritable(); err != nil {
+			return nil, err
+		}
+	}
+	return t.Txn.Commit()
+}
+
+func anyWrite(ops []clientv3.Op) bool {
+	for _, op := range ops {
+		if isWrite(op) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWrite treats nested transactions as writes since their branches are not
+// inspected.
+func isWrite(op clientv3.Op) bool {
+	return op.IsPut() || op.IsDelete() || op.IsTxn()
+}
@@ -0,0 +1,160 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type fakeKV struct {
+	clientv3.KV
+	calls int
+	rev   int64
+}
+
+func (f *fakeKV) Get(_ context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	f.calls++
+	f.rev = clientv3.OpGet(key, opts...).Rev()
+	return &clientv3.GetResponse{}, nil
+}
+
+func (f *fakeKV) Put(context.Context, string, string, ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	f.calls++
+	return &clientv3.PutResponse{}, nil
+}
+
+func (f *fakeKV) Txn(context.Context) clientv3.Txn {
+	return &fakeTxn{kv: f}
+}
+
+type fakeTxn struct {
+	clientv3.Txn
+	kv *fakeKV
+}
+
+func (t *fakeTxn) If(...clientv3.Cmp) clientv3.Txn  { return t }
+func (t *fakeTxn) Then(...clientv3.Op) clientv3.Txn { return t }
+func (t *fakeTxn) Else(...clientv3.Op) clientv3.Txn { return t }
+func (t *fakeTxn) Commit() (*clientv3.TxnResponse, error) {
+	t.kv.calls++
+	return &clientv3.TxnResponse{}, nil
+}
+
+func TestGuardedKV(t *testing.T) {
+	tests := []struct {
+		name     string
+		readOnly bool
+		run      func(kv clientv3.KV) error
+		wantErr  bool
+	}{
+		{
+			name:     "put allowed",
+			readOnly: false,
+			run: func(kv clientv3.KV) error {
+				_, err := kv.Put(context.Background(), "k", "v")
+				return err
+			},
+		},
+		{
+			name:     "put refused",
+			readOnly: true,
+			run: func(kv clientv3.KV) error {
+				_, err := kv.Put(context.Background(), "k", "v")
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:     "read-only txn allowed",
+			readOnly: true,
+			run: func(kv clientv3.KV) error {
+				_, err := kv.Txn(context.Background()).Then(clientv3.OpGet("k")).Commit()
+				return err
+			},
+		},
+		{
+			name:     "write in else branch refused",
+			readOnly: true,
+			run: func(kv clientv3.KV) error {
+				_, err := kv.Txn(context.Background()).
+					Then(clientv3.OpGet("k")).
+					Else(clientv3.OpDelete("k")).
+					Commit()
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:     "nested txn refused",
+			readOnly: true,
+			run: func(kv clientv3.KV) error {
+				_, err := kv.Txn(context.Background()).Then(clientv3.OpTxn(nil, nil, nil)).Commit()
+				return err
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &fakeKV{}
+			kv := &guardedKV{KV: inner, r: &repository{readOnly: tt.readOnly}}
+
+			err := tt.run(kv)
+			if tt.wantErr {
+				if !errors.Is(err, ErrReadOnly) {
+					t.Fatalf("err = %v, want ErrReadOnly", err)
+				}
+				if inner.calls != 0 {
+					t.Errorf("inner KV called %d times, want 0", inner.calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if inner.calls != 1 {
+				t.Errorf("inner KV called %d times, want 1", inner.calls)
+			}
+		})
+	}
+}
+
+func TestGuardedKVRevision(t *testing.T) {
+	tests := []struct {
+		name    string
+		pinned  int64
+		opts    []clientv3.OpOption
+		wantRev int64
+	}{
+		{"latest", 0, nil, 0},
+		{"pinned", 42, nil, 42},
+		{"explicit revision wins", 42, []clientv3.OpOption{clientv3.WithRev(7)}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &fakeKV{}
+			r := &repository{}
+			r.rev.Store(tt.pinned)
+			kv := &guardedKV{KV: inner, r: r}
+
+			if _, err := kv.Get(context.Background(), "k", tt.opts...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if inner.rev != tt.wantRev {
+				t.Errorf("read at revision %d, want %d", inner.rev, tt.wantRev)
+			}
+
+			_, err := kv.Put(context.Background(), "k", "v")
+			if tt.pinned > 0 && !errors.Is(err, ErrHistorical) {
+				t.Errorf("put err = %v, want ErrHistorical", err)
+			}
+			if tt.pinned == 0 && err != nil {
+				t.Errorf("put err = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// HistoryEntry is one version of a key. Raw is the value as stored.
+type HistoryEntry struct {
+	KeyValue
+	Raw string
+}
+
+// FetchHistory walks back through the versions of key, newest first, by
+// reading it again just before each mod revision. The walk stops at the
+// version that created the key, at the compaction boundary, or after limit
+// versions.
+func (r *repository) FetchHistory(key string, limit int) tea.Cmd {
+	return func() tea.Msg {
+		msg := HistoryMsg{Key: key}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var rev int64
+		for len(msg.Entries) < limit {
+			var opts []clientv3.OpOption
+			if rev > 0 {
+				opts = append(opts, clientv3.WithRev(rev))
+			}
+
+			resp, err := r.client().Get(ctx, key, opts...)
+			if errors.Is(err, rpctypes.ErrCompacted) {
+				msg.Compacted = true
+				return msg
+			}
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			if len(resp.Kvs) == 0 {
+				return msg
+			}
+
+			kv := resp.Kvs[0]
+			msg.Entries = append(msg.Entries, HistoryEntry{KeyValue: kvFromProto(kv), Raw: string(kv.Value)})
+			if kv.Version == 1 {
+				return msg
+			}
+			rev = kv.ModRevision - 1
+		}
+
+		msg.More = true
+		return msg
+	}
+}
@@ -0,0 +1,92 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// LeaseOption picks the lease a put attaches. The zero value leaves the
+// lease alone on an update and attaches none to a new key.
+type LeaseOption struct {
+	TTL    time.Duration    // grant a new lease with this TTL
+	ID     clientv3.LeaseID // attach an existing lease
+	Detach bool             // remove the key's lease
+}
+
+func (o LeaseOption) IsZero() bool {
+	return o == LeaseOption{}
+}
+
+// ParseLeaseOption parses "ttl=30s", "lease=<hex id>" or "none". A bare
+// number of seconds is accepted for ttl.
+func ParseLeaseOption(s string) (LeaseOption, error) {
+	s = strings.TrimSpace(s)
+	name, value, _ := strings.Cut(s, "=")
+
+	switch {
+	case s == "":
+		return LeaseOption{}, nil
+	case s == "none":
+		return LeaseOption{Detach: true}, nil
+	case name == "ttl":
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			secs, serr := strconv.ParseInt(value, 10, 64)
+			if serr != nil {
+				return LeaseOption{}, fmt.Errorf("invalid ttl %q", value)
+			}
+			ttl = time.Duration(secs) * time.Second
+		}
+		if ttl < time.Second {
+			return LeaseOption{}, fmt.Errorf("ttl must be at least 1s")
+		}
+		return LeaseOption{TTL: ttl}, nil
+	case name == "lease":
+		id, err := strconv.ParseInt(strings.TrimPrefix(value, "0x"), 16, 64)
+		if err != nil || id <= 0 {
+			return LeaseOption{}, fmt.Errorf("invalid lease id %q", value)
+		}
+		return LeaseOption{ID: clientv3.LeaseID(id)}, nil
+	}
+	return LeaseOption{}, fmt.Errorf("unknown lease option %q (want ttl=30s, lease=<hex id> or none)", s)
+}
+
+// resolveLease returns the lease a put with opt attaches, granting a new
+// one for a TTL. NoLease leaves the key without a lease.
+func (r *repository) resolveLease(ctx context.Context, opt LeaseOption) (clientv3.LeaseID, error) {
+	switch {
+	case opt.TTL > 0:
+		if err := r.checkWritable(); err != nil {
+			return 0, err
+		}
+		resp, err := r.client().Grant(ctx, int64(math.Ceil(opt.TTL.Seconds())))
+		if err != nil {
+			return 0, fmt.Errorf("grant lease: %w", err)
+		}
+		return resp.ID, nil
+	case opt.ID != 0:
+		return opt.ID, nil
+	}
+	return clientv3.NoLease, nil
+}
+
+// leaseTTL returns the remaining TTL of a lease in seconds, or -1 when the
+// lease has expired or cannot be looked up.
+func (r *repository) leaseTTL(ctx context.Context, id int64) int64 {
+	resp, err := r.client().TimeToLive(ctx, clientv3.LeaseID(id))
+	if err != nil {
+		return -1
+	}
+	return resp.TTL
+}
+
+// FormatLeaseID formats a lease ID the way etcdctl prints it.
+func FormatLeaseID(id int64) string {
+	return fmt.Sprintf("%016x", id)
+}
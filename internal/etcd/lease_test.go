@@ -0,0 +1,40 @@
+package etcd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLeaseOption(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected LeaseOption
+		wantErr  bool
+	}{
+		{"empty", "", LeaseOption{}, false},
+		{"none", "none", LeaseOption{Detach: true}, false},
+		{"ttl duration", "ttl=30s", LeaseOption{TTL: 30 * time.Second}, false},
+		{"ttl minutes", "ttl=5m", LeaseOption{TTL: 5 * time.Minute}, false},
+		{"ttl bare seconds", "ttl=45", LeaseOption{TTL: 45 * time.Second}, false},
+		{"ttl too short", "ttl=500ms", LeaseOption{}, true},
+		{"ttl invalid", "ttl=soon", LeaseOption{}, true},
+		{"lease hex", "lease=694d7a1b2c3d4e5f", LeaseOption{ID: 0x694d7a1b2c3d4e5f}, false},
+		{"lease 0x prefix", "lease=0x1f", LeaseOption{ID: 0x1f}, false},
+		{"lease zero", "lease=0", LeaseOption{}, true},
+		{"lease not hex", "lease=xyz", LeaseOption{}, true},
+		{"unknown", "expire=10s", LeaseOption{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseLeaseOption(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLeaseOption(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseLeaseOption(%q) = %+v, want %+v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package etcd
+
+import (
+	"context"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// forEachPage ranges over every key under prefix in key order, pageSize keys
+// at a time. All pages are read at the pinned revision, or else at the
+// revision of the first one, so the walk sees a consistent snapshot while
+// only one page is held in memory.
+func (r *repository) forEachPage(ctx context.Context, prefix string, pageSize int64, keysOnly bool, fn func(kvs []*mvccpb.KeyValue) error) error {
+	end := clientv3.GetPrefixRangeEnd(prefix)
+	key := prefix
+	if key == "" {
+		key = "\x00"
+	}
+	rev := r.Revision()
+
+	for {
+		opts := []clientv3.OpOption{
+			clientv3.WithRange(end),
+			clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+			clientv3.WithLimit(pageSize),
+		}
+		if keysOnly {
+			opts = append(opts, clientv3.WithKeysOnly())
+		}
+		if rev > 0 {
+			opts = append(opts, clientv3.WithRev(rev))
+		}
+
+		resp, err := r.client().Get(ctx, key, opts...)
+		if err != nil {
+			return err
+		}
+		if rev == 0 {
+			rev = resp.Header.Revision
+		}
+		if len(resp.Kvs) == 0 {
+			return nil
+		}
+		if err := fn(resp.Kvs); err != nil {
+			return err
+		}
+		if !resp.More {
+			return nil
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
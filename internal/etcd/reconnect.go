@@ -0,0 +1,28 @@
+package etcd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Reconnect connects a fresh client, which also fetches a new auth token.
+// The client is swapped in, and the old one closed, when the ConnectionMsg
+// reaches SetClient.
+func (r *repository) Reconnect() tea.Cmd {
+	return r.Connect
+}
+
+// UseNextEndpoint reconnects through the endpoint after the current one, for
+// when the current member is unreachable or has lost its leader.
+func (r *repository) UseNextEndpoint() tea.Cmd {
+	r.first.Add(1)
+	return r.Reconnect()
+}
+
+// rotate returns endpoints starting at index n, wrapping around.
+func rotate(endpoints []string, n int) []string {
+	if len(endpoints) == 0 {
+		return endpoints
+	}
+	n %= len(endpoints)
+	return append(endpoints[n:len(endpoints):len(endpoints)], endpoints[:n]...)
+}
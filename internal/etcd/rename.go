@@ -0,0 +1,163 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// RenameKey moves from to to in a single transaction that only commits if
+// from is unchanged since it was read and to does not exist. The key keeps
+// its lease.
+func (r *repository) RenameKey(from, to string) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return RenameMsg{From: from, To: to, Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := r.client().Get(ctx, from)
+		if err != nil {
+			return RenameMsg{From: from, To: to, Err: err}
+		}
+		if len(resp.Kvs) == 0 {
+			return RenameMsg{From: from, To: to, Err: fmt.Errorf("%s: %w", from, ErrKeyNotFound)}
+		}
+		kv := resp.Kvs[0]
+
+		txnResp, err := r.client().Txn(ctx).
+			If(
+				clientv3.Compare(clientv3.ModRevision(from), "=", kv.ModRevision),
+				clientv3.Compare(clientv3.CreateRevision(to), "=", 0),
+			).
+			Then(
+				clientv3.OpPut(to, string(kv.Value), clientv3.WithLease(clientv3.LeaseID(kv.Lease))),
+				clientv3.OpDelete(from),
+			).
+			Else(clientv3.OpGet(to, clientv3.WithCountOnly())).
+			Commit()
+		if err != nil {
+			return RenameMsg{From: from, To: to, Err: err}
+		}
+		if !txnResp.Succeeded {
+			if rr := txnResp.Responses[0].GetResponseRange(); rr != nil && rr.Count > 0 {
+				return RenameMsg{From: from, To: to, Err: fmt.Errorf("%s: %w", to, ErrKeyExists)}
+			}
+			return RenameMsg{From: from, To: to, Err: fmt.Errorf("%s: %w", from, ErrRevisionConflict)}
+		}
+
+		return RenameMsg{
+			From:    from,
+			To:      to,
+			Moved:   []KeyValue{putKeyValue([]byte(to), kv.Value, txnResp.Header.Revision, kv.Lease, nil)},
+			Removed: []string{from},
+			Changes: []Change{
+				{Key: to, Revision: txnResp.Header.Revision},
+				{Key: from, Prev: kv},
+			},
+			Revisions: []int64{txnResp.Header.Revision},
+		}
+	}
+}
+
+// RenamePrefix moves every key under from to the same suffix under to. Keys
+// are moved in chunks small enough to fit a transaction, so the move is
+// atomic per chunk but not for the whole subtree.
+func (r *repository) RenamePrefix(from, to string) tea.Cmd {
+	return func() tea.Msg {
+		msg := RenameMsg{From: from, To: to}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+		if err := checkPrefixMove(from, to); err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			resp, err := r.client().Get(ctx, from,
+				clientv3.WithPrefix(),
+				clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+				clientv3.WithLimit(renameChunkSize),
+			)
+			if err != nil {
+				cancel()
+				msg.Err = err
+				return msg
+			}
+			if len(resp.Kvs) == 0 {
+				cancel()
+				return msg
+			}
+
+			cmps, ops := moveOps(resp.Kvs, from, to)
+			txnResp, err := r.client().Txn(ctx).If(cmps...).Then(ops...).Commit()
+			cancel()
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			if !txnResp.Succeeded {
+				msg.Err = fmt.Errorf("moved %d keys, then stopped: %w or a destination key already exists", len(msg.Removed), ErrRevisionConflict)
+				return msg
+			}
+
+			msg.Revisions = append(msg.Revisions, txnResp.Header.Revision)
+			for _, kv := range resp.Kvs {
+				oldKey := string(kv.Key)
+				newKey := to + strings.TrimPrefix(oldKey, from)
+				msg.Removed = append(msg.Removed, oldKey)
+				msg.Moved = append(msg.Moved, putKeyValue([]byte(newKey), kv.Value, txnResp.Header.Revision, kv.Lease, nil))
+				msg.Changes = append(msg.Changes,
+					Change{Key: newKey, Revision: txnResp.Header.Revision},
+					Change{Key: oldKey, Prev: kv},
+				)
+			}
+		}
+	}
+}
+
+// renameChunkSize is how many keys one transaction of RenamePrefix moves.
+// Each key needs a put and a delete, plus a compare on both the source and
+// the destination.
+const renameChunkSize = MaxTxnOps / 2
+
+// checkPrefixMove refuses moves that would walk into their own output or
+// cover the whole keyspace.
+func checkPrefixMove(from, to string) error {
+	if from == "" || strings.HasPrefix(to, from) || strings.HasPrefix(from, to) {
+		return fmt.Errorf("cannot move %q to %q: prefixes overlap", from, to)
+	}
+	return nil
+}
+
+// moveOps builds the transaction that moves kvs from under from to under to,
+// committing only if every source key is unchanged and no destination key
+// exists.
+func moveOps(kvs []*mvccpb.KeyValue, from, to string) ([]clientv3.Cmp, []clientv3.Op) {
+	cmps := make([]clientv3.Cmp, 0, 2*len(kvs))
+	ops := make([]clientv3.Op, 0, 2*len(kvs))
+	for _, kv := range kvs {
+		oldKey := string(kv.Key)
+		newKey := to + strings.TrimPrefix(oldKey, from)
+
+		cmps = append(cmps,
+			clientv3.Compare(clientv3.ModRevision(oldKey), "=", kv.ModRevision),
+			clientv3.Compare(clientv3.CreateRevision(newKey), "=", 0),
+		)
+		ops = append(ops,
+			clientv3.OpPut(newKey, string(kv.Value), clientv3.WithLease(clientv3.LeaseID(kv.Lease))),
+			clientv3.OpDelete(oldKey),
+		)
+	}
+	return cmps, ops
+}
@@ -0,0 +1,52 @@
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestCheckPrefixMove(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{"/app/v1/", "/app/v2/", false},
+		{"/a/", "/b/", false},
+		{"", "/b/", true},
+		{"/app/", "/app/old/", true},
+		{"/app/old/", "/app/", true},
+		{"/app/", "/app/", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := checkPrefixMove(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkPrefixMove(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoveOpsFitATransaction(t *testing.T) {
+	kvs := make([]*mvccpb.KeyValue, renameChunkSize)
+	for i := range kvs {
+		kvs[i] = &mvccpb.KeyValue{Key: []byte(fmt.Sprintf("/old/%03d", i)), ModRevision: int64(i + 1)}
+	}
+
+	cmps, ops := moveOps(kvs, "/old/", "/new/")
+	if len(cmps) != 2*len(kvs) || len(ops) != 2*len(kvs) {
+		t.Fatalf("moveOps() = %d compares and %d ops, want %d of each", len(cmps), len(ops), 2*len(kvs))
+	}
+	if len(cmps) > MaxTxnOps || len(ops) > MaxTxnOps {
+		t.Errorf("a full chunk needs %d compares and %d ops, over the limit of %d", len(cmps), len(ops), MaxTxnOps)
+	}
+	if got := string(ops[0].KeyBytes()); got != "/new/000" || !ops[0].IsPut() {
+		t.Errorf("first op = put %q, want a put of /new/000", got)
+	}
+	if got := string(ops[1].KeyBytes()); got != "/old/000" || !ops[1].IsDelete() {
+		t.Errorf("second op = %q, want a delete of /old/000", got)
+	}
+}
@@ -0,0 +1,130 @@
+package etcd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// reportPageSize is small because report pages carry whole values.
+const reportPageSize = 100
+
+// LargestKeys walks every key under prefix a page at a time and keeps the n
+// with the largest values. Only their keys and sizes are kept, so memory is
+// bounded by n and the page size rather than by the keyspace.
+func (r *repository) LargestKeys(prefix string, n int) tea.Cmd {
+	return func() tea.Msg {
+		msg := LargestKeysMsg{Prefix: prefix}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		bySize := KeySort{Field: SortBySize, Descending: true}
+		msg.Err = r.forEachPage(ctx, prefix, reportPageSize, false, func(kvs []*mvccpb.KeyValue) error {
+			for _, kv := range kvs {
+				msg.Scanned++
+				msg.TotalBytes += int64(len(kv.Value))
+				msg.Keys = insertTop(msg.Keys, KeyValue{
+					Name:           string(kv.Key),
+					Key:            utils.SanitizeForTUI(string(kv.Key)),
+					CreateRevision: kv.CreateRevision,
+					ModRevision:    kv.ModRevision,
+					Version:        kv.Version,
+					Lease:          kv.Lease,
+					Size:           int64(len(kv.Value)),
+				}, n, bySize)
+			}
+			return nil
+		})
+		return msg
+	}
+}
+
+// UsageGroup is the rollup of the keys under one group prefix.
+type UsageGroup struct {
+	Prefix         string
+	Keys           int
+	Bytes          int64
+	NewestRevision int64
+}
+
+// Usage walks every key under prefix a page at a time and rolls them up by
+// the first depth segments after the prefix. Groups are largest first.
+func (r *repository) Usage(prefix, delimiter string, depth int) tea.Cmd {
+	return func() tea.Msg {
+		msg := UsageMsg{Prefix: prefix, Delimiter: delimiter, Depth: depth}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+		if delimiter == "" || depth < 1 {
+			msg.Err = fmt.Errorf("a delimiter and a depth of at least 1 are required")
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		groups := make(map[string]*UsageGroup)
+		msg.Err = r.forEachPage(ctx, prefix, reportPageSize, false, func(kvs []*mvccpb.KeyValue) error {
+			for _, kv := range kvs {
+				name := usageGroup(utils.SanitizeForTUI(string(kv.Key)), prefix, delimiter, depth)
+				g, ok := groups[name]
+				if !ok {
+					g = &UsageGroup{Prefix: name}
+					groups[name] = g
+				}
+				g.Keys++
+				g.Bytes += int64(len(kv.Value))
+				g.NewestRevision = max(g.NewestRevision, kv.ModRevision)
+				msg.Keys++
+				msg.Bytes += int64(len(kv.Value))
+			}
+			return nil
+		})
+
+		for _, g := range groups {
+			msg.Groups = append(msg.Groups, *g)
+		}
+		slices.SortFunc(msg.Groups, func(a, b UsageGroup) int {
+			if c := cmp.Compare(b.Bytes, a.Bytes); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Prefix, b.Prefix)
+		})
+		return msg
+	}
+}
+
+// usageGroup is the group key belongs to: key up to and including the
+// delimiter that ends the depth'th segment after prefix. Leading delimiters
+// do not start a segment, and a key with fewer segments is its own group.
+func usageGroup(key, prefix, delimiter string, depth int) string {
+	i := 0
+	if strings.HasPrefix(key, prefix) {
+		i = len(prefix)
+	}
+	for strings.HasPrefix(key[i:], delimiter) {
+		i += len(delimiter)
+	}
+	for range depth {
+		j := strings.Index(key[i:], delimiter)
+		if j < 0 {
+			return key
+		}
+		i += j + len(delimiter)
+	}
+	return key[:i]
+}
@@ -0,0 +1,32 @@
+package etcd
+
+import "testing"
+
+func TestUsageGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		prefix    string
+		delimiter string
+		depth     int
+		expected  string
+	}{
+		{"first segment", "/team/svc/x", "", "/", 1, "/team/"},
+		{"two segments", "/team/svc/x", "", "/", 2, "/team/svc/"},
+		{"shorter key is its own group", "/team", "", "/", 1, "/team"},
+		{"no leading delimiter", "team/svc", "", "/", 1, "team/"},
+		{"after prefix", "/team/svc/x", "/team/", "/", 1, "/team/svc/"},
+		{"prefix without trailing delimiter", "/team/svc/x", "/team", "/", 1, "/team/svc/"},
+		{"other delimiter", "app.prod.db", "", ".", 2, "app.prod."},
+		{"multi-character delimiter", "a::b::c", "", "::", 1, "a::"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := usageGroup(tt.key, tt.prefix, tt.delimiter, tt.depth)
+			if result != tt.expected {
+				t.Errorf("usageGroup(%q, %q, %q, %d) = %q, want %q", tt.key, tt.prefix, tt.delimiter, tt.depth, result, tt.expected)
+			}
+		})
+	}
+}
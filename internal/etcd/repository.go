@@ -6,45 +6,126 @@ import (
 	"crypto/x509"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	"github.com/olamilekan000/etcd-tui/internal/config"
 	"github.com/olamilekan000/etcd-tui/internal/utils"
 )
 
+// MaxTxnOps mirrors etcd's default --max-txn-ops; batched writes never put
+// more operations than this in a single transaction.
+const MaxTxnOps = 128
+
 type Repository interface {
 	Connect() tea.Msg
-	FetchKeys(startKey string, limit int) tea.Cmd
+	FetchKeys(sort KeySort, cursor PageCursor, limit int) tea.Cmd
 	FetchAllKeys() tea.Cmd
 	FetchTotalCount() tea.Cmd
 	FetchValue(key string) tea.Cmd
+	UpdateValue(key, value string, modRevision int64, lease LeaseOption) tea.Cmd
+	PutKey(key, value string, lease LeaseOption) tea.Cmd
+	DeleteKey(key string) tea.Cmd
+	DeleteKeys(keys []string) tea.Cmd
+	DeletePrefix(prefix string) tea.Cmd
+	CountPrefix(prefix string, sampleSize int) tea.Cmd
+	RenameKey(from, to string) tea.Cmd
+	RenamePrefix(from, to string) tea.Cmd
+	PlanClone(src, dst string) tea.Cmd
+	ClonePrefix(src, dst string, mode CollisionMode) tea.Cmd
+	Undo(changes []Change) tea.Cmd
+	ExportKeys(keys []string, path string) tea.Cmd
+	FetchHistory(key string, limit int) tea.Cmd
+	CompareKeys(left, right string) tea.Cmd
+	LargestKeys(prefix string, n int) tea.Cmd
+	Usage(prefix, delimiter string, depth int) tea.Cmd
+	WatchKey(key string, afterRevision int64) *Watcher
+	WatchPrefix(prefix string, afterRevision int64) *Watcher
+	WatchEvents(prefix string, afterRevision int64) *Watcher
+	CommitTxn(spec TxnSpec) tea.Cmd
+	PlanChangeset(entries []ChangesetEntry) tea.Cmd
+	ApplyChangeset(items []ChangesetItem) tea.Cmd
 	SetClient(client *clientv3.Client)
+	ReadOnly() bool
+	Revision() int64
+	PinRevision(rev int64) tea.Cmd
+	Reconnect() tea.Cmd
+	UseNextEndpoint() tea.Cmd
 	Close() error
 }
 
 type repository struct {
-	client *clientv3.Client
+	conn     atomic.Pointer[clientv3.Client]
+	readOnly bool
+	rev      atomic.Int64
+	first    atomic.Int64 // index of the endpoint to connect through
 }
 
 func NewRepository() Repository {
-	return &repository{}
+	r := &repository{readOnly: config.GetReadOnly()}
+	r.rev.Store(config.GetRevision())
+	return r
+}
+
+// client is the client in use. Commands run concurrently with Update, which
+// swaps it, so it is read through an atomic pointer.
+func (r *repository) client() *clientv3.Client {
+	return r.conn.Load()
 }
 
+// SetClient starts using client and closes the one it replaces. Commands
+// still running on the old client fail with a closed-client error.
 func (r *repository) SetClient(client *clientv3.Client) {
-	r.client = client
+	r.guard(client)
+	if old := r.conn.Swap(client); old != nil && old != client {
+		go old.Close()
+	}
+}
+
+func (r *repository) ReadOnly() bool {
+	return r.readOnly
+}
+
+// Revision is the revision reads are pinned to, or 0 for the latest.
+func (r *repository) Revision() int64 {
+	return r.rev.Load()
+}
+
+func (r *repository) checkWritable() error {
+	if r.readOnly {
+		return ErrReadOnly
+	}
+	if r.Revision() > 0 {
+		return ErrHistorical
+	}
+	return nil
+}
+
+// guard routes all of the client's KV calls through the write policy.
+func (r *repository) guard(client *clientv3.Client) {
+	if client == nil {
+		return
+	}
+	if _, ok := client.KV.(*guardedKV); !ok {
+		client.KV = &guardedKV{KV: client.KV, r: r}
+	}
 }
 
 func (r *repository) Close() error {
-	if r.client != nil {
-		return r.client.Close()
+	if client := r.client(); client != nil {
+		return client.Close()
 	}
 	return nil
 }
 
+// Connect dials a new client. It is not used until the ConnectionMsg that
+// carries it reaches SetClient.
 func (r *repository) Connect() tea.Msg {
 	endpoints := config.GetEndpoints()
 	if endpoints == "" {
@@ -55,7 +136,7 @@ func (r *repository) Connect() tea.Msg {
 	keyPath := config.GetKey()
 	certPath := config.GetCert()
 
-	endpointsList := strings.Split(endpoints, ",")
+	endpointsList := rotate(strings.Split(endpoints, ","), int(r.first.Load()))
 
 	var tlsConfig *tls.Config
 
@@ -114,14 +195,13 @@ func (r *repository) Connect() tea.Msg {
 		return ConnectionMsg{Success: false, Err: fmt.Errorf("failed to connect to etcd: %w", err)}
 	}
 
-	r.client = client
-
-	return ConnectionMsg{Client: client, Success: true}
+	r.guard(client)
+	return ConnectionMsg{Client: client, Endpoint: endpointsList[0], Success: true}
 }
 
-func (r *repository) FetchKeys(startKey string, limit int) tea.Cmd {
+func (r *repository) FetchKeys(sort KeySort, cursor PageCursor, limit int) tea.Cmd {
 	return func() tea.Msg {
-		if r.client == nil {
+		if r.client() == nil {
 			return KeysMsg{Err: fmt.Errorf("etcd client not initialized")}
 		}
 
@@ -132,74 +212,23 @@ func (r *repository) FetchKeys(startKey string, limit int) tea.Cmd {
 			limit = 100
 		}
 
-		var opts []clientv3.OpOption
-		var queryKey string
-
-		if startKey == "" {
-			queryKey = ""
-			opts = []clientv3.OpOption{
-				clientv3.WithPrefix(),
-				clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
-				clientv3.WithLimit(int64(limit)),
-			}
-		} else {
-			queryKey = startKey + "\x00"
-			opts = []clientv3.OpOption{
-				clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
-				clientv3.WithLimit(int64(limit)),
-				clientv3.WithFromKey(),
-			}
-		}
-
-		resp, err := r.client.Get(ctx, queryKey, opts...)
+		p, err := r.fetchPage(ctx, sort, cursor, limit)
 		if err != nil {
-			return KeysMsg{Err: err}
+			return KeysMsg{Sort: sort, Err: err}
 		}
-
-		kvPairs := make([]KeyValue, 0, len(resp.Kvs))
-		skipFirst := false
-		if startKey != "" && len(resp.Kvs) > 0 {
-			firstKey := utils.SanitizeForTUI(string(resp.Kvs[0].Key))
-			if firstKey == startKey {
-				skipFirst = true
-			}
-		}
-
-		for i, kv := range resp.Kvs {
-			if skipFirst && i == 0 {
-				continue
-			}
-
-			keyStr := utils.SanitizeForTUI(string(kv.Key))
-			valueStr := utils.SanitizeForTUI(string(kv.Value))
-			valueStr = strings.TrimSpace(valueStr)
-
-			var preview string
-			if len(valueStr) == 0 {
-				preview = "no value"
-			} else {
-				preview = utils.NormalizeForDisplay(valueStr, 50)
-			}
-
-			kvPairs = append(kvPairs, KeyValue{
-				Key:          keyStr,
-				Value:        valueStr,
-				ValuePreview: preview,
-			})
-		}
-
-		hasMore := len(resp.Kvs) >= limit
-
 		return KeysMsg{
-			Keys:    kvPairs,
-			HasMore: hasMore,
+			Keys:     p.keys,
+			HasMore:  p.more,
+			Sort:     sort,
+			Next:     p.next,
+			Revision: p.rev,
 		}
 	}
 }
 
 func (r *repository) FetchAllKeys() tea.Cmd {
 	return func() tea.Msg {
-		if r.client == nil {
+		if r.client() == nil {
 			return KeysMsg{Err: fmt.Errorf("etcd client not initialized")}
 		}
 
@@ -211,7 +240,7 @@ func (r *repository) FetchAllKeys() tea.Cmd {
 			clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
 		}
 
-		resp, err := r.client.Get(ctx, "", opts...)
+		resp, err := r.client().Get(ctx, "", opts...)
 		if err != nil {
 			return KeysMsg{Err: err}
 		}
@@ -219,22 +248,7 @@ func (r *repository) FetchAllKeys() tea.Cmd {
 		kvPairs := make([]KeyValue, 0, len(resp.Kvs))
 
 		for _, kv := range resp.Kvs {
-			keyStr := utils.SanitizeForTUI(string(kv.Key))
-			valueStr := utils.SanitizeForTUI(string(kv.Value))
-			valueStr = strings.TrimSpace(valueStr)
-
-			var preview string
-			if len(valueStr) == 0 {
-				preview = "no value"
-			} else {
-				preview = utils.NormalizeForDisplay(valueStr, 50)
-			}
-
-			kvPairs = append(kvPairs, KeyValue{
-				Key:          keyStr,
-				Value:        valueStr,
-				ValuePreview: preview,
-			})
+			kvPairs = append(kvPairs, kvFromProto(kv))
 		}
 
 		return KeysMsg{
@@ -246,14 +260,14 @@ func (r *repository) FetchAllKeys() tea.Cmd {
 
 func (r *repository) FetchTotalCount() tea.Cmd {
 	return func() tea.Msg {
-		if r.client == nil {
+		if r.client() == nil {
 			return CountMsg{Count: -1, Err: fmt.Errorf("etcd client not initialized")}
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		resp, err := r.client.Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly())
+		resp, err := r.client().Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly())
 		if err != nil {
 			return CountMsg{Count: -1, Err: err}
 		}
@@ -264,24 +278,308 @@ func (r *repository) FetchTotalCount() tea.Cmd {
 
 func (r *repository) FetchValue(key string) tea.Cmd {
 	return func() tea.Msg {
-		if r.client == nil {
+		if r.client() == nil {
 			return ValueMsg{Key: key, Err: fmt.Errorf("etcd client not initialized")}
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		resp, err := r.client.Get(ctx, key)
+		resp, err := r.client().Get(ctx, key)
 		if err != nil {
 			return ValueMsg{Key: key, Err: err}
 		}
 
-		value := ""
-		if len(resp.Kvs) > 0 {
-			value = utils.SanitizeForTUI(string(resp.Kvs[0].Value))
-			value = strings.TrimSpace(value)
+		if len(resp.Kvs) == 0 {
+			return ValueMsg{Key: key}
+		}
+
+		kv := resp.Kvs[0]
+		value := utils.SanitizeForTUI(string(kv.Value))
+		value = strings.TrimSpace(value)
+
+		msg := ValueMsg{
+			Key:            key,
+			Value:          value,
+			Raw:            string(kv.Value),
+			CreateRevision: kv.CreateRevision,
+			ModRevision:    kv.ModRevision,
+			Version:        kv.Version,
+			Lease:          kv.Lease,
+		}
+		if kv.Lease != 0 {
+			msg.LeaseTTL = r.leaseTTL(ctx, kv.Lease)
+		}
+		return msg
+	}
+}
+
+// UpdateValue writes value to key only if the key is still at modRevision.
+// A modRevision of 0 means the key is expected not to exist yet. A zero
+// lease option keeps the key's current lease.
+func (r *repository) UpdateValue(key, value string, modRevision int64, lease LeaseOption) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return PutMsg{Key: key, Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		putOpts := []clientv3.OpOption{clientv3.WithPrevKV()}
+		keepLease := lease.IsZero() && modRevision > 0
+		var leaseID clientv3.LeaseID
+		if keepLease {
+			putOpts = append(putOpts, clientv3.WithIgnoreLease())
+		} else {
+			id, err := r.resolveLease(ctx, lease)
+			if err != nil {
+				return PutMsg{Key: key, Err: err}
+			}
+			leaseID = id
+			putOpts = append(putOpts, clientv3.WithLease(id))
+		}
+
+		resp, err := r.client().Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)).
+			Then(clientv3.OpPut(key, value, putOpts...)).
+			Else(clientv3.OpGet(key, clientv3.WithKeysOnly())).
+			Commit()
+		if err != nil {
+			return PutMsg{Key: key, Err: err}
+		}
+
+		if !resp.Succeeded {
+			current := int64(0)
+			if rr := resp.Responses[0].GetResponseRange(); rr != nil && len(rr.Kvs) > 0 {
+				current = rr.Kvs[0].ModRevision
+			}
+			return PutMsg{Key: key, Err: fmt.Errorf("%w: loaded at revision %d, now at %d", ErrRevisionConflict, modRevision, current)}
+		}
+
+		prev := resp.Responses[0].GetResponsePut().GetPrevKv()
+		if keepLease && prev != nil {
+			leaseID = clientv3.LeaseID(prev.Lease)
+		}
+		return PutMsg{
+			Key:      key,
+			KV:       putKeyValue([]byte(key), []byte(value), resp.Header.Revision, int64(leaseID), prev),
+			Revision: resp.Header.Revision,
+			Created:  modRevision == 0,
+			Changes:  []Change{{Key: key, Prev: prev, Revision: resp.Header.Revision}},
+		}
+	}
+}
+
+// PutKey creates key. It never overwrites: if the key exists the write is
+// refused with ErrKeyExists.
+func (r *repository) PutKey(key, value string, lease LeaseOption) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return PutMsg{Key: key, Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		leaseID, err := r.resolveLease(ctx, lease)
+		if err != nil {
+			return PutMsg{Key: key, Err: err}
+		}
+
+		resp, err := r.client().Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+			Then(clientv3.OpPut(key, value, clientv3.WithLease(leaseID))).
+			Else(clientv3.OpGet(key)).
+			Commit()
+		if err != nil {
+			return PutMsg{Key: key, Err: err}
+		}
+
+		if !resp.Succeeded {
+			msg := PutMsg{Key: key, Err: fmt.Errorf("%s: %w", key, ErrKeyExists)}
+			if rr := resp.Responses[0].GetResponseRange(); rr != nil && len(rr.Kvs) > 0 {
+				msg.KV = kvFromProto(rr.Kvs[0])
+			}
+			return msg
+		}
+
+		return PutMsg{
+			Key:      key,
+			KV:       putKeyValue([]byte(key), []byte(value), resp.Header.Revision, int64(leaseID), nil),
+			Revision: resp.Header.Revision,
+			Created:  true,
+			Changes:  []Change{{Key: key, Revision: resp.Header.Revision}},
+		}
+	}
+}
+
+func (r *repository) DeleteKey(key string) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return DeleteMsg{Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := r.client().Delete(ctx, key, clientv3.WithPrevKV())
+		if err != nil {
+			return DeleteMsg{Err: fmt.Errorf("%s: %w", key, err)}
+		}
+
+		msg := DeleteMsg{Deleted: resp.Deleted, Changes: deleteChanges(resp.PrevKvs)}
+		if resp.Deleted > 0 {
+			msg.Keys = []string{key}
+			msg.Revisions = []int64{resp.Header.Revision}
+		} else {
+			msg.Missing = []string{key}
+		}
+		return msg
+	}
+}
+
+// DeleteKeys deletes keys in transactions of at most MaxTxnOps operations.
+func (r *repository) DeleteKeys(keys []string) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return DeleteMsg{Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		var msg DeleteMsg
+
+		for batch := range slices.Chunk(keys, MaxTxnOps) {
+			ops := make([]clientv3.Op, 0, len(batch))
+			for _, key := range batch {
+				ops = append(ops, clientv3.OpDelete(key, clientv3.WithPrevKV()))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			resp, err := r.client().Txn(ctx).Then(ops...).Commit()
+			cancel()
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+
+			var deleted int64
+			for i, op := range resp.Responses {
+				dr := op.GetResponseDeleteRange()
+				if dr.Deleted > 0 {
+					msg.Keys = append(msg.Keys, batch[i])
+				} else {
+					msg.Missing = append(msg.Missing, batch[i])
+				}
+				deleted += dr.Deleted
+				msg.Changes = append(msg.Changes, deleteChanges(dr.PrevKvs)...)
+			}
+			if deleted > 0 {
+				msg.Revisions = append(msg.Revisions, resp.Header.Revision)
+			}
+			msg.Deleted += deleted
+		}
+
+		return msg
+	}
+}
+
+func (r *repository) DeletePrefix(prefix string) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return DeleteMsg{Err: fmt.Errorf("etcd client not initialized")}
+		}
+		if prefix == "" {
+			return DeleteMsg{Err: fmt.Errorf("refusing to delete with an empty prefix")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		resp, err := r.client().Delete(ctx, prefix, clientv3.WithPrefix(), clientv3.WithPrevKV())
+		if err != nil {
+			return DeleteMsg{Err: fmt.Errorf("%s: %w", prefix, err)}
+		}
+
+		msg := DeleteMsg{Prefix: prefix, Deleted: resp.Deleted, Changes: deleteChanges(resp.PrevKvs)}
+		if resp.Deleted > 0 {
+			msg.Revisions = []int64{resp.Header.Revision}
 		}
+		return msg
+	}
+}
+
+// CountPrefix counts the keys under prefix and returns the first sampleSize
+// of them, without loading any values.
+func (r *repository) CountPrefix(prefix string, sampleSize int) tea.Cmd {
+	return func() tea.Msg {
+		if r.client() == nil {
+			return PrefixCountMsg{Prefix: prefix, Err: fmt.Errorf("etcd client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		countResp, err := r.client().Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		if err != nil {
+			return PrefixCountMsg{Prefix: prefix, Err: err}
+		}
+
+		sampleResp, err := r.client().Get(ctx, prefix,
+			clientv3.WithPrefix(),
+			clientv3.WithKeysOnly(),
+			clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+			clientv3.WithLimit(int64(sampleSize)),
+		)
+		if err != nil {
+			return PrefixCountMsg{Prefix: prefix, Err: err}
+		}
+
+		sample := make([]string, 0, len(sampleResp.Kvs))
+		for _, kv := range sampleResp.Kvs {
+			sample = append(sample, utils.SanitizeForTUI(string(kv.Key)))
+		}
+
+		return PrefixCountMsg{Prefix: prefix, Count: countResp.Count, Sample: sample}
+	}
+}
+
+func newKeyValue(key, value []byte) KeyValue {
+	keyStr := utils.SanitizeForTUI(string(key))
+	valueStr := utils.SanitizeForTUI(string(value))
+	valueStr = strings.TrimSpace(valueStr)
+
+	var preview string
+	if len(valueStr) == 0 {
+		preview = "no value"
+	} else {
+		preview = utils.NormalizeForDisplay(valueStr, 50)
+	}
+
+	return KeyValue{
+		Name:         string(key),
+		Key:          keyStr,
+		Value:        valueStr,
+		ValuePreview: preview,
+		Size:         int64(len(value)),
+	}
+}
+
+func kvFromProto(kv *mvccpb.KeyValue) KeyValue {
+	out := newKeyValue(kv.Key, kv.Value)
+	out.CreateRevision = kv.CreateRevision
+	out.ModRevision = kv.ModRevision
+	out.Version = kv.Version
+	out.Lease = kv.Lease
+	return out
+}
 
-		return ValueMsg{Key: key, Value: value}
+// putKeyValue is the key as a put at rev left it. prev is the key before the
+// put, or nil when the put created it.
+func putKeyValue(key, value []byte, rev, lease int64, prev *mvccpb.KeyValue) KeyValue {
+	kv := &mvccpb.KeyValue{Key: key, Value: value, CreateRevision: rev, ModRevision: rev, Version: 1, Lease: lease}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
 	}
+	return kvFromProto(kv)
 }
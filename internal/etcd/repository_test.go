@@ -0,0 +1,44 @@
+package etcd
+
+import (
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestPutKeyValue(t *testing.T) {
+	tests := []struct {
+		name                           string
+		prev                           *mvccpb.KeyValue
+		createRev, modRev, wantVersion int64
+	}{
+		{"created", nil, 50, 50, 1},
+		{"overwritten", &mvccpb.KeyValue{CreateRevision: 12, ModRevision: 40, Version: 3}, 12, 50, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kv := putKeyValue([]byte("/a"), []byte(" v \n"), 50, 7, tt.prev)
+			if kv.Key != "/a" || kv.Value != "v" {
+				t.Errorf("putKeyValue() key/value = %q/%q, want /a/v", kv.Key, kv.Value)
+			}
+			if kv.CreateRevision != tt.createRev || kv.ModRevision != tt.modRev || kv.Version != tt.wantVersion {
+				t.Errorf("putKeyValue() revisions = %d/%d/%d, want %d/%d/%d",
+					kv.CreateRevision, kv.ModRevision, kv.Version, tt.createRev, tt.modRev, tt.wantVersion)
+			}
+			if kv.Lease != 7 {
+				t.Errorf("putKeyValue() lease = %d, want 7", kv.Lease)
+			}
+		})
+	}
+}
+
+func TestNewKeyValueKeepsName(t *testing.T) {
+	kv := newKeyValue([]byte("/caf\xc3\xa9/\x1b[31mx"), nil)
+	if kv.Name != "/caf\xc3\xa9/\x1b[31mx" {
+		t.Errorf("Name = %q, want the key unchanged", kv.Name)
+	}
+	if kv.Key != "/caf/[31mx" {
+		t.Errorf("Key = %q, want /caf/[31mx", kv.Key)
+	}
+}
@@ -0,0 +1,68 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// PinRevision checks that rev can still be read and, if so, pins every
+// following read to it. A rev of 0 goes back to reading the latest revision.
+func (r *repository) PinRevision(rev int64) tea.Cmd {
+	return func() tea.Msg {
+		msg := RevisionMsg{Revision: rev}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+		if rev < 0 {
+			msg.Err = fmt.Errorf("invalid revision %d", rev)
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := r.client().Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly(), clientv3.WithRev(rev))
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		msg.Current = resp.Header.Revision
+		r.rev.Store(rev)
+		return msg
+	}
+}
+
+// revisionErr explains why a read at rev failed when the revision itself is
+// the problem, and returns err unchanged otherwise.
+func (r *repository) revisionErr(ctx context.Context, rev int64, err error) error {
+	switch {
+	case rev <= 0:
+		return err
+	case errors.Is(err, rpctypes.ErrCompacted):
+		return &CompactedError{Revision: rev, CompactRevision: r.compactRevision(ctx, rev)}
+	case errors.Is(err, rpctypes.ErrFutureRev):
+		return fmt.Errorf("revision %d has not been written yet: %w", rev, err)
+	}
+	return err
+}
+
+// compactRevision asks for the compact revision the only way etcd reports
+// it: by watching from a compacted revision.
+func (r *repository) compactRevision(ctx context.Context, rev int64) int64 {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	select {
+	case resp := <-r.client().Watch(ctx, "\x00", clientv3.WithRev(rev)):
+		return resp.CompactRevision
+	case <-ctx.Done():
+		return 0
+	}
+}
@@ -0,0 +1,337 @@
+package etcd
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// SortField is what the key list is ordered by.
+type SortField int
+
+const (
+	SortByKey SortField = iota
+	SortByModRevision
+	SortByCreateRevision
+	SortByVersion
+	SortBySize
+)
+
+var sortFieldNames = map[SortField]string{
+	SortByKey:            "key",
+	SortByModRevision:    "mod revision",
+	SortByCreateRevision: "create revision",
+	SortByVersion:        "version",
+	SortBySize:           "size",
+}
+
+// KeySort is the order of the key list. The zero value is by key, ascending.
+type KeySort struct {
+	Field      SortField
+	Descending bool
+}
+
+func (s KeySort) String() string {
+	arrow := "↑"
+	if s.Descending {
+		arrow = "↓"
+	}
+	return sortFieldNames[s.Field] + " " + arrow
+}
+
+// Next moves on to the next field in its natural direction: keys ascending,
+// everything else newest or largest first.
+func (s KeySort) Next() KeySort {
+	field := (s.Field + 1) % (SortBySize + 1)
+	return KeySort{Field: field, Descending: field != SortByKey}
+}
+
+// Reverse flips the direction.
+func (s KeySort) Reverse() KeySort {
+	s.Descending = !s.Descending
+	return s
+}
+
+// Compare orders a and b by the sort field, falling back to the key so that
+// the order is total.
+func (s KeySort) Compare(a, b KeyValue) int {
+	var c int
+	switch s.Field {
+	case SortByModRevision:
+		c = cmp.Compare(a.ModRevision, b.ModRevision)
+	case SortByCreateRevision:
+		c = cmp.Compare(a.CreateRevision, b.CreateRevision)
+	case SortByVersion:
+		c = cmp.Compare(a.Version, b.Version)
+	case SortBySize:
+		c = cmp.Compare(a.Size, b.Size)
+	}
+	if s.Descending {
+		c = -c
+	}
+	if c == 0 {
+		c = cmp.Compare(a.Key, b.Key)
+		if s.Field == SortByKey && s.Descending {
+			c = -c
+		}
+	}
+	return c
+}
+
+// SortKeys sorts keys in place.
+func SortKeys(keys []KeyValue, s KeySort) {
+	if s != (KeySort{}) {
+		slices.SortFunc(keys, s.Compare)
+	}
+}
+
+// PageCursor is where the next page of the key list starts. The zero value
+// is the first page. Which fields are used depends on the sort:
+//   - by key, the last key returned;
+//   - by revision, the last revision returned and the keys already returned
+//     at it, because a transaction can write many keys at one revision;
+//   - by version or size, which etcd cannot range over, the number of keys
+//     returned so far and the revision of the first page.
+type PageCursor struct {
+	Key      string
+	Rev      int64
+	Seen     []string
+	Offset   int
+	Snapshot int64
+}
+
+func (s KeySort) order() clientv3.SortOrder {
+	if s.Descending {
+		return clientv3.SortDescend
+	}
+	return clientv3.SortAscend
+}
+
+// page is one page of the key list. rev is the revision it was read at, or
+// 0 when it was put together from several reads.
+type page struct {
+	keys []KeyValue
+	more bool
+	next PageCursor
+	rev  int64
+}
+
+// fetchPage reads the page of keys after c in the order s.
+func (r *repository) fetchPage(ctx context.Context, s KeySort, c PageCursor, limit int) (page, error) {
+	switch s.Field {
+	case SortByModRevision, SortByCreateRevision:
+		return r.fetchRevisionPage(ctx, s, c, limit)
+	case SortByVersion:
+		return r.fetchOffsetPage(ctx, s, c, limit)
+	case SortBySize:
+		return r.fetchLargestPage(ctx, s, c, limit)
+	}
+	return r.fetchKeyPage(ctx, s, c, limit)
+}
+
+func (r *repository) fetchKeyPage(ctx context.Context, s KeySort, c PageCursor, limit int) (page, error) {
+	key := "\x00"
+	opts := []clientv3.OpOption{
+		clientv3.WithSort(clientv3.SortByKey, s.order()),
+		clientv3.WithLimit(int64(limit)),
+	}
+	switch {
+	case c.Key == "":
+		opts = append(opts, clientv3.WithFromKey())
+	case s.Descending:
+		opts = append(opts, clientv3.WithRange(c.Key))
+	default:
+		key = c.Key + "\x00"
+		opts = append(opts, clientv3.WithFromKey())
+	}
+
+	resp, err := r.client().Get(ctx, key, opts...)
+	if err != nil {
+		return page{}, err
+	}
+	if len(resp.Kvs) > 0 {
+		c.Key = string(resp.Kvs[len(resp.Kvs)-1].Key)
+	}
+	return page{keysOf(resp.Kvs), resp.More, c, resp.Header.Revision}, nil
+}
+
+// fetchRevisionPage pages with a min or max revision filter starting at the
+// last revision returned. Keys at that revision that were already returned
+// are dropped, and if a single revision holds more keys than fit on a page
+// it is read whole so that the walk always moves on.
+func (r *repository) fetchRevisionPage(ctx context.Context, s KeySort, c PageCursor, limit int) (page, error) {
+	target, bound := clientv3.SortByModRevision, revisionBound(s)
+	if s.Field == SortByCreateRevision {
+		target = clientv3.SortByCreateRevision
+	}
+
+	get := func(opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+		opts = append(opts, clientv3.WithFromKey(), clientv3.WithSort(target, s.order()))
+		return r.client().Get(ctx, "\x00", opts...)
+	}
+
+	opts := []clientv3.OpOption{clientv3.WithLimit(int64(limit))}
+	if c.Rev > 0 {
+		opts = append(opts, bound(c.Rev))
+	}
+	resp, err := get(opts...)
+	if err != nil {
+		return page{}, err
+	}
+
+	kvs := unseen(resp.Kvs, c.Seen)
+	if len(kvs) == 0 && resp.More {
+		resp, err = get(minRevision(s)(c.Rev), maxRevision(s)(c.Rev))
+		if err != nil {
+			return page{}, err
+		}
+		kvs = unseen(resp.Kvs, c.Seen)
+		c.Seen = nil
+		if s.Descending {
+			c.Rev--
+		} else {
+			c.Rev++
+		}
+		if c.Rev <= 0 {
+			return page{keysOf(kvs), false, c, resp.Header.Revision}, nil
+		}
+		if len(kvs) == 0 {
+			return r.fetchRevisionPage(ctx, s, c, limit)
+		}
+		return page{keysOf(kvs), true, c, resp.Header.Revision}, nil
+	}
+
+	for _, kv := range kvs {
+		if rev := revisionOf(s, kv); rev != c.Rev {
+			c.Rev = rev
+			c.Seen = nil
+		}
+		c.Seen = append(c.Seen, string(kv.Key))
+	}
+	return page{keysOf(kvs), resp.More, c, resp.Header.Revision}, nil
+}
+
+func keysOf(kvs []*mvccpb.KeyValue) []KeyValue {
+	keys := make([]KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		keys = append(keys, kvFromProto(kv))
+	}
+	return keys
+}
+
+func revisionOf(s KeySort, kv *mvccpb.KeyValue) int64 {
+	if s.Field == SortByCreateRevision {
+		return kv.CreateRevision
+	}
+	return kv.ModRevision
+}
+
+func minRevision(s KeySort) func(int64) clientv3.OpOption {
+	if s.Field == SortByCreateRevision {
+		return clientv3.WithMinCreateRev
+	}
+	return clientv3.WithMinModRev
+}
+
+func maxRevision(s KeySort) func(int64) clientv3.OpOption {
+	if s.Field == SortByCreateRevision {
+		return clientv3.WithMaxCreateRev
+	}
+	return clientv3.WithMaxModRev
+}
+
+// revisionBound is the filter that continues the walk from a revision.
+func revisionBound(s KeySort) func(int64) clientv3.OpOption {
+	if s.Descending {
+		return maxRevision(s)
+	}
+	return minRevision(s)
+}
+
+func unseen(kvs []*mvccpb.KeyValue, seen []string) []*mvccpb.KeyValue {
+	if len(seen) == 0 {
+		return kvs
+	}
+	return slices.DeleteFunc(kvs, func(kv *mvccpb.KeyValue) bool {
+		return slices.Contains(seen, string(kv.Key))
+	})
+}
+
+// fetchOffsetPage asks etcd for the first Offset+limit keys in sort order and
+// keeps the ones after Offset. etcd sorts the whole range on every request,
+// so reading every page at the first page's revision keeps the order stable.
+func (r *repository) fetchOffsetPage(ctx context.Context, s KeySort, c PageCursor, limit int) (page, error) {
+	if c.Snapshot == 0 {
+		c.Snapshot = r.Revision()
+	}
+	opts := []clientv3.OpOption{
+		clientv3.WithFromKey(),
+		clientv3.WithSort(clientv3.SortByVersion, s.order()),
+		clientv3.WithLimit(int64(c.Offset + limit)),
+	}
+	if c.Snapshot > 0 {
+		opts = append(opts, clientv3.WithRev(c.Snapshot))
+	}
+	resp, err := r.client().Get(ctx, "\x00", opts...)
+	if err != nil {
+		return page{}, err
+	}
+	if c.Snapshot == 0 {
+		c.Snapshot = resp.Header.Revision
+	}
+
+	var keys []KeyValue
+	if c.Offset < len(resp.Kvs) {
+		keys = keysOf(resp.Kvs[c.Offset:])
+	}
+	c.Offset += len(keys)
+	return page{keys, resp.More, c, c.Snapshot}, nil
+}
+
+// fetchLargestPage walks the whole keyspace a page at a time, keeping only
+// the first Offset+limit keys in size order, and returns the ones after
+// Offset. etcd cannot sort by size, so every page costs a full walk, but
+// memory stays bounded by the number of keys shown.
+func (r *repository) fetchLargestPage(ctx context.Context, s KeySort, c PageCursor, limit int) (page, error) {
+	top, total, err := r.topKeys(ctx, s, c.Offset+limit)
+	if err != nil {
+		return page{}, err
+	}
+	var keys []KeyValue
+	if c.Offset < len(top) {
+		keys = top[c.Offset:]
+	}
+	c.Offset += len(keys)
+	return page{keys, total > c.Offset, c, 0}, nil
+}
+
+// topKeys returns the first n keys of the keyspace in the order s, along
+// with the number of keys walked.
+func (r *repository) topKeys(ctx context.Context, s KeySort, n int) ([]KeyValue, int, error) {
+	top := make([]KeyValue, 0, n)
+	total := 0
+	err := r.forEachPage(ctx, "", 500, false, func(kvs []*mvccpb.KeyValue) error {
+		for _, kv := range kvs {
+			total++
+			top = insertTop(top, kvFromProto(kv), n, s)
+		}
+		return nil
+	})
+	return top, total, err
+}
+
+// insertTop inserts kv into top, which is in the order s, keeping at most n
+// keys.
+func insertTop(top []KeyValue, kv KeyValue, n int, s KeySort) []KeyValue {
+	i, _ := slices.BinarySearchFunc(top, kv, s.Compare)
+	if i >= n {
+		return top
+	}
+	if len(top) == n {
+		top = top[:n-1]
+	}
+	return slices.Insert(top, i, kv)
+}
@@ -0,0 +1,86 @@
+package etcd
+
+import (
+	"slices"
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestKeySortNext(t *testing.T) {
+	var got []string
+	s := KeySort{}
+	for range 6 {
+		got = append(got, s.String())
+		s = s.Next()
+	}
+	want := []string{"key ↑", "mod revision ↓", "create revision ↓", "version ↓", "size ↓", "key ↑"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Next() cycle = %q, want %q", got, want)
+	}
+	if r := (KeySort{}).Reverse(); r.String() != "key ↓" {
+		t.Errorf("Reverse() = %q, want key ↓", r)
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	keys := func() []KeyValue {
+		return []KeyValue{
+			{Key: "/a", ModRevision: 5, CreateRevision: 1, Version: 3, Size: 10},
+			{Key: "/b", ModRevision: 9, CreateRevision: 2, Version: 1, Size: 30},
+			{Key: "/c", ModRevision: 5, CreateRevision: 3, Version: 2, Size: 20},
+		}
+	}
+
+	tests := []struct {
+		name string
+		sort KeySort
+		want []string
+	}{
+		{"key ascending", KeySort{}, []string{"/a", "/b", "/c"}},
+		{"key descending", KeySort{Descending: true}, []string{"/c", "/b", "/a"}},
+		{"newest first, ties by key", KeySort{Field: SortByModRevision, Descending: true}, []string{"/b", "/a", "/c"}},
+		{"oldest first", KeySort{Field: SortByModRevision}, []string{"/a", "/c", "/b"}},
+		{"created last first", KeySort{Field: SortByCreateRevision, Descending: true}, []string{"/c", "/b", "/a"}},
+		{"most versions first", KeySort{Field: SortByVersion, Descending: true}, []string{"/a", "/c", "/b"}},
+		{"largest first", KeySort{Field: SortBySize, Descending: true}, []string{"/b", "/c", "/a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kvs := keys()
+			SortKeys(kvs, tt.sort)
+			var got []string
+			for _, kv := range kvs {
+				got = append(got, kv.Key)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortKeys(%v) = %q, want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertTop(t *testing.T) {
+	s := KeySort{Field: SortBySize, Descending: true}
+	var top []KeyValue
+	for i, size := range []int64{5, 50, 1, 20, 50, 7} {
+		top = insertTop(top, KeyValue{Key: string(rune('a' + i)), Size: size}, 3, s)
+	}
+
+	var got []string
+	for _, kv := range top {
+		got = append(got, kv.Key)
+	}
+	if want := []string{"b", "e", "d"}; !slices.Equal(got, want) {
+		t.Errorf("insertTop() = %q, want %q", got, want)
+	}
+}
+
+func TestUnseen(t *testing.T) {
+	kvs := []*mvccpb.KeyValue{{Key: []byte("/a")}, {Key: []byte("/b")}, {Key: []byte("/c")}}
+	got := unseen(kvs, []string{"/a", "/c"})
+	if len(got) != 1 || string(got[0].Key) != "/b" {
+		t.Errorf("unseen() = %v, want only /b", got)
+	}
+}
@@ -0,0 +1,251 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// CompareTarget is the part of a key a transaction compare looks at.
+type CompareTarget string
+
+const (
+	CompareValue   CompareTarget = "value"
+	CompareVersion CompareTarget = "version"
+	CompareCreate  CompareTarget = "create"
+	CompareMod     CompareTarget = "mod"
+	CompareLease   CompareTarget = "lease"
+)
+
+// TxnCompare is one compare clause. Value holds the text to compare against;
+// for every target but value it must be a number, or a hex ID for lease.
+type TxnCompare struct {
+	Key    string
+	Target CompareTarget
+	Op     string
+	Value  string
+}
+
+// ParseCompare validates the parts of a compare clause as entered by a user.
+func ParseCompare(key, target, op, value string) (TxnCompare, error) {
+	c := TxnCompare{
+		Key:    key,
+		Target: CompareTarget(strings.ToLower(strings.TrimSpace(target))),
+		Op:     strings.TrimSpace(op),
+		Value:  value,
+	}
+	if key == "" {
+		return c, fmt.Errorf("key is required")
+	}
+	switch c.Op {
+	case "=", "!=", "<", ">":
+	default:
+		return c, fmt.Errorf("unknown operator %q (want =, !=, < or >)", c.Op)
+	}
+	switch c.Target {
+	case CompareValue:
+	case CompareVersion, CompareCreate, CompareMod, CompareLease:
+		c.Value = strings.TrimSpace(value)
+		if _, err := c.number(); err != nil {
+			return c, err
+		}
+	default:
+		return c, fmt.Errorf("unknown target %q (want value, version, create, mod or lease)", target)
+	}
+	return c, nil
+}
+
+func (c TxnCompare) number() (int64, error) {
+	if c.Target == CompareLease {
+		if c.Value == "0" {
+			return 0, nil
+		}
+		id, err := strconv.ParseInt(strings.TrimPrefix(c.Value, "0x"), 16, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid lease id %q", c.Value)
+		}
+		return id, nil
+	}
+	n, err := strconv.ParseInt(c.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s needs a number, got %q", c.Target, c.Value)
+	}
+	return n, nil
+}
+
+func (c TxnCompare) cmp() (clientv3.Cmp, error) {
+	if c.Target == CompareValue {
+		return clientv3.Compare(clientv3.Value(c.Key), c.Op, c.Value), nil
+	}
+	n, err := c.number()
+	if err != nil {
+		return clientv3.Cmp{}, err
+	}
+	switch c.Target {
+	case CompareVersion:
+		return clientv3.Compare(clientv3.Version(c.Key), c.Op, n), nil
+	case CompareCreate:
+		return clientv3.Compare(clientv3.CreateRevision(c.Key), c.Op, n), nil
+	case CompareMod:
+		return clientv3.Compare(clientv3.ModRevision(c.Key), c.Op, n), nil
+	default:
+		return clientv3.Compare(clientv3.LeaseValue(c.Key), c.Op, n), nil
+	}
+}
+
+// String renders the clause in etcdctl's txn syntax.
+func (c TxnCompare) String() string {
+	if c.Target == CompareValue {
+		return fmt.Sprintf("value(%q) %s %q", c.Key, c.Op, c.Value)
+	}
+	return fmt.Sprintf("%s(%q) %s %s", c.Target, c.Key, c.Op, c.Value)
+}
+
+type TxnOpType string
+
+const (
+	TxnGet    TxnOpType = "get"
+	TxnPut    TxnOpType = "put"
+	TxnDelete TxnOpType = "delete"
+)
+
+type TxnOp struct {
+	Type  TxnOpType
+	Key   string
+	Value string
+}
+
+// ParseTxnOp validates an operation as entered by a user.
+func ParseTxnOp(typ, key, value string) (TxnOp, error) {
+	op := TxnOp{Type: TxnOpType(strings.ToLower(strings.TrimSpace(typ))), Key: key, Value: value}
+	if key == "" {
+		return op, fmt.Errorf("key is required")
+	}
+	switch op.Type {
+	case TxnGet, TxnDelete:
+		op.Value = ""
+	case TxnPut:
+	default:
+		return op, fmt.Errorf("unknown operation %q (want get, put or delete)", typ)
+	}
+	return op, nil
+}
+
+func (o TxnOp) op() clientv3.Op {
+	switch o.Type {
+	case TxnPut:
+		return clientv3.OpPut(o.Key, o.Value, clientv3.WithPrevKV())
+	case TxnDelete:
+		return clientv3.OpDelete(o.Key, clientv3.WithPrevKV())
+	default:
+		return clientv3.OpGet(o.Key)
+	}
+}
+
+// String renders the operation in etcdctl's txn syntax.
+func (o TxnOp) String() string {
+	if o.Type == TxnPut {
+		return fmt.Sprintf("put %q %q", o.Key, o.Value)
+	}
+	return fmt.Sprintf("%s %q", o.Type, o.Key)
+}
+
+// TxnSpec describes a transaction: if every compare holds, Success runs,
+// otherwise Failure does.
+type TxnSpec struct {
+	Compares []TxnCompare
+	Success  []TxnOp
+	Failure  []TxnOp
+}
+
+func (s TxnSpec) Empty() bool {
+	return len(s.Compares) == 0 && len(s.Success) == 0 && len(s.Failure) == 0
+}
+
+// TxnResult is the response to one operation of the branch that ran. KVs
+// holds what a get found or what a put wrote; Deleted is set for deletes.
+type TxnResult struct {
+	Op      TxnOp
+	KVs     []KeyValue
+	Deleted int64
+}
+
+// CommitTxn runs spec as a single transaction.
+func (r *repository) CommitTxn(spec TxnSpec) tea.Cmd {
+	return func() tea.Msg {
+		msg := TxnMsg{Spec: spec}
+		if r.client() == nil {
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+		// Compares always see the latest revision, so a transaction
+		// makes no sense while browsing a past one.
+		if r.Revision() > 0 {
+			msg.Err = ErrHistorical
+			return msg
+		}
+		if len(spec.Compares) > MaxTxnOps || len(spec.Success) > MaxTxnOps || len(spec.Failure) > MaxTxnOps {
+			msg.Err = fmt.Errorf("a transaction can hold at most %d compares and %d operations per branch", MaxTxnOps, MaxTxnOps)
+			return msg
+		}
+
+		cmps := make([]clientv3.Cmp, 0, len(spec.Compares))
+		for _, c := range spec.Compares {
+			cmp, err := c.cmp()
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			cmps = append(cmps, cmp)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		resp, err := r.client().Txn(ctx).If(cmps...).Then(txnOps(spec.Success)...).Else(txnOps(spec.Failure)...).Commit()
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		msg.Succeeded = resp.Succeeded
+		msg.Revision = resp.Header.Revision
+		ops := spec.Failure
+		if resp.Succeeded {
+			ops = spec.Success
+		}
+
+		for i, op := range ops {
+			result := TxnResult{Op: op}
+			switch op.Type {
+			case TxnGet:
+				for _, kv := range resp.Responses[i].GetResponseRange().GetKvs() {
+					result.KVs = append(result.KVs, kvFromProto(kv))
+				}
+			case TxnPut:
+				prev := resp.Responses[i].GetResponsePut().GetPrevKv()
+				result.KVs = []KeyValue{putKeyValue([]byte(op.Key), []byte(op.Value), resp.Header.Revision, 0, prev)}
+				msg.Changes = append(msg.Changes, Change{Key: op.Key, Prev: prev, Revision: resp.Header.Revision})
+			case TxnDelete:
+				dr := resp.Responses[i].GetResponseDeleteRange()
+				result.Deleted = dr.GetDeleted()
+				msg.Changes = append(msg.Changes, deleteChanges(dr.GetPrevKvs())...)
+			}
+			msg.Results = append(msg.Results, result)
+		}
+		return msg
+	}
+}
+
+func txnOps(ops []TxnOp) []clientv3.Op {
+	out := make([]clientv3.Op, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, op.op())
+	}
+	return out
+}
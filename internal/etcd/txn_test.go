@@ -0,0 +1,66 @@
+package etcd
+
+import "testing"
+
+func TestParseCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		target   string
+		op       string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"mod revision", "/a", "mod", "=", "42", `mod("/a") = 42`, false},
+		{"target case and spaces", "/a", " Version ", ">", " 3 ", `version("/a") > 3`, false},
+		{"value keeps spaces", "/a", "value", "!=", " x ", `value("/a") != " x "`, false},
+		{"lease hex", "/a", "lease", "=", "1f", `lease("/a") = 1f`, false},
+		{"no lease", "/a", "lease", "=", "0", `lease("/a") = 0`, false},
+		{"create needs number", "/a", "create", "=", "abc", "", true},
+		{"unknown target", "/a", "size", "=", "1", "", true},
+		{"unknown operator", "/a", "mod", "<=", "1", "", true},
+		{"missing key", "", "mod", "=", "1", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCompare(tt.key, tt.target, tt.op, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCompare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && c.String() != tt.expected {
+				t.Errorf("ParseCompare().String() = %s, want %s", c.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTxnOp(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		key      string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"put", "put", "/a", "1", `put "/a" "1"`, false},
+		{"get drops value", "GET", "/a", "ignored", `get "/a"`, false},
+		{"delete", "delete", "/a", "", `delete "/a"`, false},
+		{"unknown", "watch", "/a", "", "", true},
+		{"missing key", "put", "", "1", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := ParseTxnOp(tt.typ, tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTxnOp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && op.String() != tt.expected {
+				t.Errorf("ParseTxnOp().String() = %s, want %s", op.String(), tt.expected)
+			}
+		})
+	}
+}
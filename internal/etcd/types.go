@@ -1,31 +1,252 @@
 package etcd
 
-import clientv3 "go.etcd.io/etcd/client/v3"
+import (
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
 
+// KeyValue is a key as shown in the table. Name is the key exactly as stored
+// and is what reads and writes must use; Key is Name made safe to display.
+// The revision fields and Lease are etcd's metadata for the key and are zero
+// when unknown.
 type KeyValue struct {
-	Key          string
-	Value        string
-	ValuePreview string
+	Name           string
+	Key            string
+	Value          string
+	ValuePreview   string
+	CreateRevision int64
+	ModRevision    int64
+	Version        int64
+	Lease          int64
+	Size           int64 // length of the raw value in bytes
 }
 
+// ConnectionMsg reports the outcome of connecting. Endpoint is the one the
+// connection was checked through.
 type ConnectionMsg struct {
-	Client  *clientv3.Client
-	Success bool
-	Err     error
+	Client   *clientv3.Client
+	Endpoint string
+	Success  bool
+	Err      error
 }
 
+// KeysMsg carries a page of the key list in the order Sort. Next is where
+// the page after it starts, and Revision is the revision the page was read
+// at, or 0 when unknown.
 type KeysMsg struct {
-	Keys    []KeyValue
-	HasMore bool
-	Err     error
+	Keys     []KeyValue
+	HasMore  bool
+	Sort     KeySort
+	Next     PageCursor
+	Revision int64
+	Err      error
 }
 
+// ValueMsg carries a single key's value. LeaseTTL is the lease's remaining
+// time in seconds and is only looked up when Lease is set.
 type ValueMsg struct {
-	Key   string
-	Value string
+	Key            string
+	Value          string
+	Raw            string
+	CreateRevision int64
+	ModRevision    int64
+	Version        int64
+	Lease          int64
+	LeaseTTL       int64
+	Err            error
+}
+
+// Change records one write so that it can be undone. Prev is the key as it
+// was before the write, with its value, lease and mod revision, and is nil
+// when the write created the key. Revision is the key's mod revision after
+// the write, or 0 when the write deleted it.
+type Change struct {
+	Key      string
+	Prev     *mvccpb.KeyValue
+	Revision int64
+}
+
+// PutMsg reports a write to one key. When a create finds the key taken, Err
+// wraps ErrKeyExists and KV is the existing key.
+type PutMsg struct {
+	Key      string
+	KV       KeyValue
+	Revision int64
+	Created  bool
+	Changes  []Change
+	Err      error
+}
+
+// DeleteMsg reports a delete. Keys lists the keys that were removed, Missing
+// the ones asked for that were already gone, and Prefix is set when a whole
+// prefix was removed. They may be set alongside Err when a batched delete
+// fails part way through. Revisions are the revisions the delete wrote at.
+type DeleteMsg struct {
+	Keys      []string
+	Missing   []string
+	Prefix    string
+	Deleted   int64
+	Changes   []Change
+	Revisions []int64
+	Err       error
+}
+
+// RenameMsg reports a key or prefix move. Moved holds the new keys and
+// Removed the old ones; a prefix move that fails part way through reports
+// the chunks that did complete alongside Err. Revisions are the revisions
+// the completed chunks wrote at.
+type RenameMsg struct {
+	From      string
+	To        string
+	Moved     []KeyValue
+	Removed   []string
+	Changes   []Change
+	Revisions []int64
+	Err       error
+}
+
+type ClonePlanMsg struct {
+	Source     string
+	Dest       string
+	Count      int
+	Collisions []string
+	Err        error
+}
+
+type CloneMsg struct {
+	Source      string
+	Dest        string
+	Copied      []KeyValue
+	Skipped     int
+	Overwritten int
+	Changes     []Change
+	Revisions   []int64
+	Err         error
+}
+
+// UndoMsg reports an undo. Restored holds the keys that were put back and
+// Removed the keys that were deleted again. Reverted lists the changes that
+// were undone and Revisions the new mod revision of every key put back.
+// Written lists the revisions the undo wrote at. Pending holds the changes
+// left untouched because of Err.
+type UndoMsg struct {
+	Restored  []KeyValue
+	Removed   []string
+	Reverted  []Change
+	Revisions map[string]int64
+	Written   []int64
+	Pending   []Change
+	Err       error
+}
+
+// WatchEvent is a single put or delete seen by a watch. Value is the raw
+// value and is empty for deletes; Revision is the revision of the change.
+// KV is the key as the change left it; for a delete only its key and
+// revision are set. Prev is the key before the change, for watches that ask
+// for it, and nil when the change created the key.
+type WatchEvent struct {
+	Key      string
+	Deleted  bool
+	Value    string
+	Revision int64
+	KV       KeyValue
+	Prev     *KeyValue
+}
+
+// WatchMsg carries one watch response. Watcher identifies the watch it came
+// from; Closed is set once the watch has ended.
+type WatchMsg struct {
+	Watcher  *Watcher
+	Events   []WatchEvent
+	Revision int64
+	Closed   bool
+	Err      error
+}
+
+// TxnMsg reports a committed transaction. Results holds one entry per
+// operation of the branch that ran.
+type TxnMsg struct {
+	Spec      TxnSpec
+	Succeeded bool
+	Revision  int64
+	Results   []TxnResult
+	Changes   []Change
+	Err       error
+}
+
+type ChangesetPlanMsg struct {
+	Items []ChangesetItem
 	Err   error
 }
 
+type ChangesetMsg struct {
+	Applied int
+	Changes []Change
+	Err     error
+}
+
+// HistoryMsg lists the versions of a key, newest first. Compacted is set when
+// older versions were lost to compaction and More when the walk stopped at
+// its limit.
+type HistoryMsg struct {
+	Key       string
+	Entries   []HistoryEntry
+	Compacted bool
+	More      bool
+	Err       error
+}
+
+// CompareMsg carries the two keys being compared, both read at Revision.
+type CompareMsg struct {
+	Left     KeyValue
+	Right    KeyValue
+	Revision int64
+	Err      error
+}
+
+// LargestKeysMsg lists the keys under Prefix with the largest values, largest
+// first, without their values. Scanned and TotalBytes cover every key walked.
+type LargestKeysMsg struct {
+	Prefix     string
+	Keys       []KeyValue
+	Scanned    int
+	TotalBytes int64
+	Err        error
+}
+
+// UsageMsg rolls up the keys under Prefix by their first Depth segments.
+// Keys and Bytes cover every key walked.
+type UsageMsg struct {
+	Prefix    string
+	Delimiter string
+	Depth     int
+	Groups    []UsageGroup
+	Keys      int
+	Bytes     int64
+	Err       error
+}
+
+// RevisionMsg reports the outcome of pinning reads to Revision. Current is
+// the cluster's latest revision.
+type RevisionMsg struct {
+	Revision int64
+	Current  int64
+	Err      error
+}
+
+type ExportMsg struct {
+	Path  string
+	Count int
+	Err   error
+}
+
+type PrefixCountMsg struct {
+	Prefix string
+	Count  int64
+	Sample []string
+	Err    error
+}
+
 type CountMsg struct {
 	Count int
 	Err   error
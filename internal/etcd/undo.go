@@ -0,0 +1,111 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Undo reverts changes in transactions of at most MaxTxnOps keys. Each
+// transaction only commits if every key in it is still exactly as the
+// change left it; keys are put back with their previous lease.
+func (r *repository) Undo(changes []Change) tea.Cmd {
+	return func() tea.Msg {
+		msg := UndoMsg{Revisions: make(map[string]int64)}
+		if r.client() == nil {
+			msg.Pending = changes
+			msg.Err = fmt.Errorf("etcd client not initialized")
+			return msg
+		}
+
+		done := 0
+		for batch := range slices.Chunk(changes, MaxTxnOps) {
+			cmps := make([]clientv3.Cmp, 0, len(batch))
+			ops := make([]clientv3.Op, 0, len(batch))
+			for _, c := range batch {
+				cmps = append(cmps, undoGuard(c))
+				if c.Prev == nil {
+					ops = append(ops, clientv3.OpDelete(c.Key))
+				} else {
+					ops = append(ops, clientv3.OpPut(c.Key, string(c.Prev.Value), clientv3.WithLease(clientv3.LeaseID(c.Prev.Lease))))
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			resp, err := r.client().Txn(ctx).If(cmps...).Then(ops...).Commit()
+			cancel()
+			if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+				err = fmt.Errorf("%w: keys under it cannot be restored", ErrLeaseExpired)
+			}
+			if err != nil {
+				msg.Pending = changes[done:]
+				msg.Err = err
+				return msg
+			}
+			if !resp.Succeeded {
+				msg.Pending = changes[done:]
+				msg.Err = fmt.Errorf("%w: a key was changed after the session wrote it", ErrRevisionConflict)
+				return msg
+			}
+
+			for _, c := range batch {
+				if c.Prev == nil {
+					msg.Removed = append(msg.Removed, c.Key)
+					continue
+				}
+				// A key the write deleted comes back new; otherwise it is still
+				// the key the write left at Revision.
+				var current *mvccpb.KeyValue
+				if c.Revision != 0 {
+					current = &mvccpb.KeyValue{CreateRevision: c.Prev.CreateRevision, Version: c.Prev.Version + 1}
+				}
+				msg.Restored = append(msg.Restored, putKeyValue(c.Prev.Key, c.Prev.Value, resp.Header.Revision, c.Prev.Lease, current))
+				msg.Revisions[c.Key] = resp.Header.Revision
+			}
+			msg.Reverted = append(msg.Reverted, batch...)
+			msg.Written = append(msg.Written, resp.Header.Revision)
+			done += len(batch)
+		}
+
+		return msg
+	}
+}
+
+// undoGuard matches the state a change left the key in: still at the
+// revision it wrote, or still absent after a delete.
+func undoGuard(c Change) clientv3.Cmp {
+	if c.Revision == 0 {
+		return clientv3.Compare(clientv3.CreateRevision(c.Key), "=", 0)
+	}
+	return clientv3.Compare(clientv3.ModRevision(c.Key), "=", c.Revision)
+}
+
+func deleteChanges(prev []*mvccpb.KeyValue) []Change {
+	changes := make([]Change, 0, len(prev))
+	for _, kv := range prev {
+		changes = append(changes, Change{Key: string(kv.Key), Prev: kv})
+	}
+	return changes
+}
+
+// Rebase points older changes at the revisions this undo wrote, so that a
+// key undone here can be undone again further back.
+func (msg UndoMsg) Rebase(changes []Change) {
+	for _, undone := range msg.Reverted {
+		if undone.Prev == nil {
+			continue
+		}
+		for i := range changes {
+			if changes[i].Key == undone.Key && changes[i].Revision == undone.Prev.ModRevision {
+				changes[i].Revision = msg.Revisions[undone.Key]
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package etcd
+
+import (
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestUndoMsgRebase(t *testing.T) {
+	// /a was edited at revision 10 and again at 12; undoing the second edit
+	// put the first value back at revision 15.
+	msg := UndoMsg{
+		Reverted: []Change{
+			{Key: "/a", Prev: &mvccpb.KeyValue{Key: []byte("/a"), ModRevision: 10}, Revision: 12},
+			{Key: "/b", Revision: 12},
+		},
+		Revisions: map[string]int64{"/a": 15},
+	}
+
+	tests := []struct {
+		name   string
+		change Change
+		want   int64
+	}{
+		{"earlier write to the undone key", Change{Key: "/a", Revision: 10}, 15},
+		{"write at another revision", Change{Key: "/a", Revision: 9}, 9},
+		{"other key at the same revision", Change{Key: "/c", Revision: 10}, 10},
+		{"created key that was removed again", Change{Key: "/b", Revision: 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := []Change{tt.change}
+			msg.Rebase(changes)
+			if changes[0].Revision != tt.want {
+				t.Errorf("Revision = %d, want %d", changes[0].Revision, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package etcd
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// Watcher delivers the responses of one watch as WatchMsgs. Next has to be
+// called again after every message to receive the following one, and Stop
+// ends the watch. A nil Watcher is inert.
+type Watcher struct {
+	Key    string
+	from   int64
+	ch     clientv3.WatchChan
+	cancel context.CancelFunc
+}
+
+// WatchKey watches key for changes made after afterRevision, or from now on
+// when afterRevision is 0. Nothing is watched while reads are pinned to a
+// past revision.
+func (r *repository) WatchKey(key string, afterRevision int64) *Watcher {
+	return r.watch(key, afterRevision)
+}
+
+// WatchPrefix is WatchKey for every key under prefix; an empty prefix
+// watches the whole keyspace. Its first message, without events, arrives
+// once the watch is established.
+func (r *repository) WatchPrefix(prefix string, afterRevision int64) *Watcher {
+	return r.watch(prefix, afterRevision, clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+}
+
+// WatchEvents is WatchPrefix with the previous version of every changed key
+// attached to its event.
+func (r *repository) WatchEvents(prefix string, afterRevision int64) *Watcher {
+	return r.watch(prefix, afterRevision, clientv3.WithPrefix(), clientv3.WithCreatedNotify(), clientv3.WithPrevKV())
+}
+
+func (r *repository) watch(key string, afterRevision int64, opts ...clientv3.OpOption) *Watcher {
+	if r.client() == nil || r.Revision() > 0 {
+		return nil
+	}
+
+	var from int64
+	if afterRevision > 0 {
+		from = afterRevision + 1
+		opts = append(opts, clientv3.WithRev(from))
+	}
+
+	ctx, cancel := context.WithCancel(clientv3.WithRequireLeader(context.Background()))
+	return &Watcher{Key: key, from: from, ch: r.client().Watch(ctx, key, opts...), cancel: cancel}
+}
+
+func (w *Watcher) Next() tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		resp, ok := <-w.ch
+		if !ok {
+			return WatchMsg{Watcher: w, Closed: true}
+		}
+		// The compact revision is left out: pinning reads to it does not
+		// help a watch.
+		if resp.CompactRevision != 0 {
+			return WatchMsg{Watcher: w, Err: &CompactedError{Revision: w.from}}
+		}
+		if err := resp.Err(); err != nil {
+			return WatchMsg{Watcher: w, Err: err}
+		}
+
+		events := make([]WatchEvent, 0, len(resp.Events))
+		for _, ev := range resp.Events {
+			event := WatchEvent{
+				Key:      utils.SanitizeForTUI(string(ev.Kv.Key)),
+				Deleted:  ev.Type == mvccpb.DELETE,
+				Value:    string(ev.Kv.Value),
+				Revision: ev.Kv.ModRevision,
+				KV:       kvFromProto(ev.Kv),
+			}
+			if ev.PrevKv != nil {
+				prev := kvFromProto(ev.PrevKv)
+				event.Prev = &prev
+			}
+			events = append(events, event)
+		}
+		return WatchMsg{Watcher: w, Events: events, Revision: resp.Header.Revision}
+	}
+}
+
+func (w *Watcher) Stop() {
+	if w != nil {
+		w.cancel()
+	}
+}
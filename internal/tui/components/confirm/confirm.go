@@ -0,0 +1,185 @@
+package confirm
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/olamilekan000/etcd-tui/internal/tui/style"
+)
+
+// ResultMsg is sent once the user answers the dialog. Choice holds the label
+// that was picked and is empty when the dialog was cancelled.
+type ResultMsg struct {
+	ID        string
+	Confirmed bool
+	Choice    string
+}
+
+type Model struct {
+	id       string
+	title    string
+	body     string
+	choices  []string
+	selected int
+	yesNo    bool
+	width    int
+
+	expected string
+	input    textinput.Model
+	err      string
+}
+
+// New returns a yes/no dialog. "No" is selected initially so that a stray
+// enter never confirms a destructive action.
+func New(id, title, body string) Model {
+	return Model{
+		id:       id,
+		title:    title,
+		body:     body,
+		choices:  []string{"Yes", "No"},
+		selected: 1,
+		yesNo:    true,
+		width:    60,
+	}
+}
+
+// WithChoices replaces Yes/No with custom answers. Each answer can be picked
+// with its first letter, and the last one is selected initially.
+func (m Model) WithChoices(choices ...string) Model {
+	m.choices = choices
+	m.selected = len(choices) - 1
+	m.yesNo = false
+	return m
+}
+
+// WithTypedConfirmation requires the user to type expected before the dialog
+// can be confirmed.
+func (m Model) WithTypedConfirmation(expected string) Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Focus()
+	m.expected = expected
+	m.input = ti
+	return m
+}
+
+func (m Model) typed() bool {
+	return m.expected != ""
+}
+
+func (m Model) Init() tea.Cmd {
+	if m.typed() {
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (m Model) ID() string {
+	return m.id
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.typed() {
+		return m.updateTyped(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key := keyMsg.String(); key {
+	case "esc", "q":
+		return m, m.cancel()
+	case "enter":
+		return m, m.result(m.selected)
+	case "left", "h", "shift+tab":
+		m.selected = (m.selected - 1 + len(m.choices)) % len(m.choices)
+	case "right", "l", "tab":
+		m.selected = (m.selected + 1) % len(m.choices)
+	default:
+		for i, choice := range m.choices {
+			if strings.EqualFold(key, choice[:1]) {
+				return m, m.result(i)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateTyped(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return m, m.cancel()
+		case "enter":
+			if m.input.Value() == m.expected {
+				return m, m.result(0)
+			}
+			m.err = "input does not match"
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) result(idx int) tea.Cmd {
+	msg := ResultMsg{ID: m.id, Confirmed: true, Choice: m.choices[idx]}
+	if m.yesNo && idx != 0 {
+		msg.Confirmed = false
+	}
+	return func() tea.Msg { return msg }
+}
+
+func (m Model) cancel() tea.Cmd {
+	id := m.id
+	return func() tea.Msg { return ResultMsg{ID: id} }
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(style.FormTitle.Render(m.title))
+	b.WriteString("\n\n")
+	b.WriteString(m.body)
+	b.WriteString("\n\n")
+
+	if m.typed() {
+		b.WriteString("Type " + style.Bold.Render(m.expected) + " to confirm:\n")
+		b.WriteString(m.input.View())
+		b.WriteString("\n")
+		if m.err != "" {
+			b.WriteString(style.Error.Render(m.err))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(style.KeyHelpDesc.Render("enter confirm • esc cancel"))
+		return style.Modal.Width(m.width).Render(b.String())
+	}
+
+	buttons := make([]string, 0, 2*len(m.choices))
+	for i, choice := range m.choices {
+		button := style.Button
+		if i == m.selected {
+			button = style.ButtonActive
+		}
+		if i > 0 {
+			buttons = append(buttons, "  ")
+		}
+		buttons = append(buttons, button.Render(choice))
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, buttons...))
+	b.WriteString("\n\n")
+	b.WriteString(style.KeyHelpDesc.Render("first letter answers • ←/→ choose • enter select • esc cancel"))
+
+	return style.Modal.Width(m.width).Render(b.String())
+}
@@ -0,0 +1,92 @@
+package confirm
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func press(m Model, key string) (Model, tea.Msg) {
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "right":
+		msg = tea.KeyMsg{Type: tea.KeyRight}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	m, cmd := m.Update(msg)
+	if cmd == nil {
+		return m, nil
+	}
+	return m, cmd()
+}
+
+func TestYesNo(t *testing.T) {
+	tests := []struct {
+		name          string
+		keys          []string
+		wantConfirmed bool
+		wantChoice    string
+	}{
+		{"y confirms", []string{"y"}, true, "Yes"},
+		{"n declines", []string{"n"}, false, "No"},
+		{"enter defaults to no", []string{"enter"}, false, "No"},
+		{"move then enter", []string{"right", "enter"}, true, "Yes"},
+		{"esc cancels", []string{"esc"}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New("id", "title", "body")
+			var msg tea.Msg
+			for _, k := range tt.keys {
+				m, msg = press(m, k)
+			}
+			result, ok := msg.(ResultMsg)
+			if !ok {
+				t.Fatalf("expected ResultMsg, got %T", msg)
+			}
+			if result.Confirmed != tt.wantConfirmed || result.Choice != tt.wantChoice {
+				t.Errorf("got Confirmed=%v Choice=%q, want %v %q", result.Confirmed, result.Choice, tt.wantConfirmed, tt.wantChoice)
+			}
+		})
+	}
+}
+
+func TestWithChoices(t *testing.T) {
+	m := New("id", "title", "body").WithChoices("Skip", "Overwrite", "Abort")
+
+	_, msg := press(m, "o")
+	result := msg.(ResultMsg)
+	if !result.Confirmed || result.Choice != "Overwrite" {
+		t.Errorf("got Confirmed=%v Choice=%q, want true Overwrite", result.Confirmed, result.Choice)
+	}
+
+	_, msg = press(m, "enter")
+	result = msg.(ResultMsg)
+	if result.Choice != "Abort" {
+		t.Errorf("default choice = %q, want Abort", result.Choice)
+	}
+}
+
+func TestTypedConfirmation(t *testing.T) {
+	m := New("id", "title", "body").WithTypedConfirmation("/svc/")
+
+	m, msg := press(m, "enter")
+	if msg != nil {
+		t.Fatalf("empty input should not answer the dialog, got %v", msg)
+	}
+
+	for _, r := range "/svc/" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, msg = press(m, "enter")
+	result, ok := msg.(ResultMsg)
+	if !ok || !result.Confirmed {
+		t.Errorf("matching input should confirm, got %v", msg)
+	}
+}
@@ -0,0 +1,381 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/style"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// OpenMsg asks the parent to show the previous and new value of Entry.
+type OpenMsg struct {
+	Entry Entry
+}
+
+// RestartMsg asks the parent to watch again after the watch ended, from the
+// last revision seen.
+type RestartMsg struct {
+	Prefix   string
+	Revision int64
+}
+
+// CloseMsg is sent when the user leaves the event log.
+type CloseMsg struct{}
+
+// Entry is one event in the log and when it arrived.
+type Entry struct {
+	Time  time.Time
+	Event etcd.WatchEvent
+	seq   int
+}
+
+// Model is a log of the watch events under one prefix, oldest first. At most
+// limit events are kept. While paused, events are held back and appended on
+// resume.
+type Model struct {
+	prefix   string
+	limit    int
+	entries  []Entry
+	pending  []Entry
+	paused   bool
+	seq      int
+	revision int64
+	watching bool
+	err      error
+
+	filter    textinput.Model
+	filtering bool
+	visible   []int
+	cursor    int
+	offset    int
+	width     int
+	height    int
+}
+
+func New(prefix string, limit int) Model {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	return Model{prefix: prefix, limit: limit, filter: ti}
+}
+
+func (m Model) Prefix() string {
+	return m.prefix
+}
+
+// Revision is the last revision the watch reported.
+func (m Model) Revision() int64 {
+	return m.revision
+}
+
+// Filtering reports whether the filter is being typed, so that the parent
+// leaves every key to it.
+func (m Model) Filtering() bool {
+	return m.filtering
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.scroll()
+}
+
+// SetWatching records that the watch is running and has seen revision.
+func (m *Model) SetWatching(revision int64) {
+	m.watching = true
+	m.err = nil
+	if revision > m.revision {
+		m.revision = revision
+	}
+}
+
+// SetErr records that the watch ended, with err or, when nil, because it was
+// closed. After a compaction the events since the last revision cannot be
+// replayed, so resuming starts from now.
+func (m *Model) SetErr(err error) {
+	m.watching = false
+	m.err = err
+	if err == nil {
+		m.err = errors.New("the watch was closed")
+	}
+	var compacted *etcd.CompactedError
+	if errors.As(err, &compacted) {
+		m.revision = 0
+	}
+}
+
+// Append adds events that arrived at t. While the cursor is on the newest
+// event it follows the new ones.
+func (m *Model) Append(events []etcd.WatchEvent, t time.Time) {
+	for _, ev := range events {
+		m.seq++
+		entry := Entry{Time: t, Event: ev, seq: m.seq}
+		if m.paused {
+			m.pending = append(m.pending, entry)
+			if len(m.pending) > m.limit {
+				m.pending = m.pending[len(m.pending)-m.limit:]
+			}
+			continue
+		}
+		m.add(entry)
+	}
+}
+
+func (m *Model) add(entries ...Entry) {
+	following := m.cursor >= len(m.visible)-1
+	selected := m.selectedSeq()
+
+	m.entries = append(m.entries, entries...)
+	if len(m.entries) > m.limit {
+		m.entries = m.entries[len(m.entries)-m.limit:]
+	}
+	m.refilter()
+
+	if following {
+		m.cursor = utils.Max(0, len(m.visible)-1)
+	} else {
+		m.selectSeq(selected)
+	}
+	m.scroll()
+}
+
+// Entries returns the events shown, oldest first.
+func (m Model) Entries() []Entry {
+	entries := make([]Entry, 0, len(m.visible))
+	for _, i := range m.visible {
+		entries = append(entries, m.entries[i])
+	}
+	return entries
+}
+
+func (m Model) selectedSeq() int {
+	if m.cursor < len(m.visible) {
+		return m.entries[m.visible[m.cursor]].seq
+	}
+	return 0
+}
+
+// selectSeq moves the cursor to the event seq, or to the oldest event when
+// it is no longer shown.
+func (m *Model) selectSeq(seq int) {
+	m.cursor = 0
+	for i, idx := range m.visible {
+		if m.entries[idx].seq >= seq {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+func (m *Model) refilter() {
+	m.visible = nil
+	text := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	for i, e := range m.entries {
+		if text == "" || matches(e, text) {
+			m.visible = append(m.visible, i)
+		}
+	}
+}
+
+// matches reports whether the event's type, key or value contains text,
+// which is lower case.
+func matches(e Entry, text string) bool {
+	return strings.Contains(strings.ToLower(eventType(e.Event)), text) ||
+		strings.Contains(strings.ToLower(e.Event.Key), text) ||
+		strings.Contains(strings.ToLower(preview(e.Event)), text)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.filtering {
+		return m.updateFilter(keyMsg)
+	}
+
+	last := utils.Max(0, len(m.visible)-1)
+	switch keyMsg.String() {
+	case "esc", "q":
+		if m.filter.Value() != "" {
+			m.setFilter("")
+			return m, nil
+		}
+		return m, func() tea.Msg { return CloseMsg{} }
+	case "up", "k":
+		m.cursor = utils.Max(0, m.cursor-1)
+	case "down", "j":
+		m.cursor = utils.Min(m.cursor+1, last)
+	case "g":
+		m.cursor = 0
+	case "G":
+		m.cursor = last
+	case "p":
+		m.paused = !m.paused
+		if !m.paused {
+			pending := m.pending
+			m.pending = nil
+			m.add(pending...)
+		}
+	case "c":
+		m.entries, m.pending, m.visible = nil, nil, nil
+		m.cursor, m.offset = 0, 0
+	case "/":
+		m.filtering = true
+		m.filter.Focus()
+		return m, textinput.Blink
+	case "r":
+		if !m.watching {
+			prefix, rev := m.prefix, m.revision
+			return m, func() tea.Msg { return RestartMsg{Prefix: prefix, Revision: rev} }
+		}
+	case "enter":
+		if m.cursor < len(m.visible) {
+			entry := m.entries[m.visible[m.cursor]]
+			return m, func() tea.Msg { return OpenMsg{Entry: entry} }
+		}
+	}
+	m.scroll()
+	return m, nil
+}
+
+// updateFilter narrows the log as the filter is typed. Enter keeps the
+// filter and esc drops it.
+func (m Model) updateFilter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.filtering = false
+		m.filter.Blur()
+		return m, nil
+	case "esc":
+		m.filtering = false
+		m.filter.Blur()
+		m.setFilter("")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	selected := m.selectedSeq()
+	m.refilter()
+	m.selectSeq(selected)
+	m.scroll()
+	return m, cmd
+}
+
+func (m *Model) setFilter(text string) {
+	selected := m.selectedSeq()
+	m.filter.SetValue(text)
+	m.refilter()
+	m.selectSeq(selected)
+	m.scroll()
+}
+
+// rows is the number of events that fit below the title, column header,
+// footer, help and filter.
+func (m Model) rows() int {
+	return utils.Max(1, m.height-6)
+}
+
+func (m *Model) scroll() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+m.rows() {
+		m.offset = m.cursor - m.rows() + 1
+	}
+}
+
+func eventType(ev etcd.WatchEvent) string {
+	if ev.Deleted {
+		return "DELETE"
+	}
+	return "PUT"
+}
+
+// preview is the new value, or for a delete the value that was deleted.
+func preview(ev etcd.WatchEvent) string {
+	if !ev.Deleted {
+		return ev.KV.ValuePreview
+	}
+	if ev.Prev != nil {
+		return ev.Prev.ValuePreview
+	}
+	return ""
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	where := m.prefix
+	if where == "" {
+		where = "all keys"
+	}
+	title := style.TableHeader.Render("Watch") + ": " + utils.Truncate(where, utils.Max(10, m.width-30))
+	if m.paused {
+		title += "  " + style.Banner.Render(fmt.Sprintf(" PAUSED, %d new ", len(m.pending)))
+	}
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	b.WriteString(style.RowNumber.Render(fmt.Sprintf("  %-8s  %-6s  %9s  %s", "Time", "Type", "Revision", "Key / Value")))
+	b.WriteString("\n")
+
+	end := utils.Min(len(m.visible), m.offset+m.rows())
+	for i := m.offset; i < end; i++ {
+		e := m.entries[m.visible[i]]
+		line := fmt.Sprintf("  %-8s  %-6s  %9d  %s  %s", e.Time.Format("15:04:05"), eventType(e.Event), e.Event.Revision, e.Event.Key, preview(e.Event))
+		line = utils.Truncate(line, utils.Max(10, m.width-2))
+		switch {
+		case i == m.cursor:
+			line = style.SelectedRow.Render(line)
+		case e.Event.Deleted:
+			line = style.DiffDel.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	for i := end - m.offset; i < m.rows(); i++ {
+		b.WriteString("\n")
+	}
+
+	if m.filtering || m.filter.Value() != "" {
+		b.WriteString(m.filter.View())
+	}
+	b.WriteString("\n")
+	b.WriteString(m.footer())
+	b.WriteString("\n")
+	b.WriteString(style.KeyHelpDesc.Render(m.help()))
+	return b.String()
+}
+
+func (m Model) footer() string {
+	count := fmt.Sprintf("%d events", len(m.entries))
+	if len(m.visible) != len(m.entries) {
+		count = fmt.Sprintf("%d of %d events", len(m.visible), len(m.entries))
+	}
+	switch {
+	case m.err != nil:
+		return style.Error.Render("watch ended: "+m.err.Error()) + style.KeyHelpDesc.Render(" · r resumes")
+	case !m.watching:
+		return style.KeyHelpDesc.Render("starting the watch...")
+	case m.revision > 0:
+		return style.KeyHelpDesc.Render(fmt.Sprintf("%s · watching at revision %d", count, m.revision))
+	}
+	return style.KeyHelpDesc.Render(count + " · watching")
+}
+
+func (m Model) help() string {
+	if m.filtering {
+		return "enter keep filter • esc clear filter"
+	}
+	pause := "p pause"
+	if m.paused {
+		pause = "p resume"
+	}
+	return "enter details • " + pause + " • c clear • / filter • tab details pane • esc close"
+}
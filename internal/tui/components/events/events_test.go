@@ -0,0 +1,92 @@
+package events
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+)
+
+func press(m Model, keys ...string) Model {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func put(key string, rev int64) etcd.WatchEvent {
+	return etcd.WatchEvent{Key: key, Revision: rev, KV: etcd.KeyValue{Key: key, ModRevision: rev}}
+}
+
+func revisions(m Model) []int64 {
+	var revs []int64
+	for _, e := range m.Entries() {
+		revs = append(revs, e.Event.Revision)
+	}
+	return revs
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		keys  []string
+		want  []int64
+	}{
+		{"keeps every event", 10, nil, []int64{1, 2, 3, 4}},
+		{"drops the oldest beyond the limit", 3, nil, []int64{2, 3, 4}},
+		{"holds events back while paused", 10, []string{"p"}, []int64{1, 2}},
+		{"appends held events on resume", 10, []string{"p", "p"}, []int64{1, 2, 3, 4}},
+		{"clears", 10, []string{"c"}, []int64{3, 4}},
+		{"filters by key", 10, []string{"/", "b", "enter"}, []int64{2, 4}},
+		{"esc drops the filter", 10, []string{"/", "b", "esc"}, []int64{1, 2, 3, 4}},
+		{"filters by type", 10, []string{"/", "d", "e", "l", "enter"}, []int64{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New("", tt.limit)
+			m.Append([]etcd.WatchEvent{put("/a", 1), put("/b", 2)}, time.Now())
+			m = press(m, tt.keys...)
+			del := put("/b", 4)
+			del.Deleted = true
+			m.Append([]etcd.WatchEvent{put("/a", 3), del}, time.Now())
+
+			if got := revisions(m); !slices.Equal(got, tt.want) {
+				t.Errorf("revisions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollow(t *testing.T) {
+	m := New("", 10)
+	m.Append([]etcd.WatchEvent{put("/a", 1), put("/b", 2)}, time.Now())
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d, want 1 on the newest event", m.cursor)
+	}
+
+	m = press(m, "k")
+	m.Append([]etcd.WatchEvent{put("/c", 3)}, time.Now())
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0: it should stay on the selected event", m.cursor)
+	}
+
+	m = press(m, "G")
+	m.Append([]etcd.WatchEvent{put("/d", 4)}, time.Now())
+	if m.cursor != 3 {
+		t.Errorf("cursor = %d, want 3: it should follow new events", m.cursor)
+	}
+}
@@ -0,0 +1,231 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/tui/style"
+)
+
+// Field describes one input of a form. Multiline fields accept newlines and
+// are edited with a textarea instead of a single-line input.
+type Field struct {
+	Label       string
+	Value       string
+	Placeholder string
+	Multiline   bool
+}
+
+// SubmitMsg is sent when the user submits the form. Values are in field order.
+type SubmitMsg struct {
+	ID     string
+	Values []string
+}
+
+// CancelMsg is sent when the user closes the form without submitting.
+type CancelMsg struct {
+	ID string
+}
+
+type input struct {
+	label     string
+	multiline bool
+	line      textinput.Model
+	area      textarea.Model
+}
+
+type Model struct {
+	id     string
+	title  string
+	inputs []input
+	focus  int
+	width  int
+	err    string
+}
+
+func New(id, title string, fields ...Field) Model {
+	m := Model{id: id, title: title, width: 60}
+	for _, f := range fields {
+		in := input{label: f.Label, multiline: f.Multiline}
+		if f.Multiline {
+			ta := textarea.New()
+			ta.ShowLineNumbers = false
+			ta.CharLimit = 0
+			ta.MaxHeight = 0
+			ta.Placeholder = f.Placeholder
+			ta.SetHeight(8)
+			ta.SetValue(f.Value)
+			ta.Blur()
+			in.area = ta
+		} else {
+			ti := textinput.New()
+			ti.Prompt = ""
+			ti.Placeholder = f.Placeholder
+			ti.SetValue(f.Value)
+			ti.CursorEnd()
+			in.line = ti
+		}
+		m.inputs = append(m.inputs, in)
+	}
+	m.SetWidth(m.width)
+	m.setFocus(0)
+	return m
+}
+
+func (m Model) ID() string {
+	return m.id
+}
+
+func (m Model) Values() []string {
+	values := make([]string, len(m.inputs))
+	for i, in := range m.inputs {
+		if in.multiline {
+			values[i] = in.area.Value()
+		} else {
+			values[i] = in.line.Value()
+		}
+	}
+	return values
+}
+
+func (m *Model) SetError(err string) {
+	m.err = err
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	inner := width - 4
+	if inner < 10 {
+		inner = 10
+	}
+	for i := range m.inputs {
+		if m.inputs[i].multiline {
+			m.inputs[i].area.SetWidth(inner)
+		} else {
+			m.inputs[i].line.Width = inner - 1
+		}
+	}
+}
+
+func (m *Model) setFocus(idx int) tea.Cmd {
+	if len(m.inputs) == 0 {
+		return nil
+	}
+	m.focus = (idx + len(m.inputs)) % len(m.inputs)
+
+	var cmd tea.Cmd
+	for i := range m.inputs {
+		in := &m.inputs[i]
+		if i == m.focus {
+			if in.multiline {
+				cmd = in.area.Focus()
+			} else {
+				cmd = in.line.Focus()
+			}
+			continue
+		}
+		if in.multiline {
+			in.area.Blur()
+		} else {
+			in.line.Blur()
+		}
+	}
+	return cmd
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		multiline := len(m.inputs) > 0 && m.inputs[m.focus].multiline
+		switch keyMsg.String() {
+		case "esc":
+			id := m.id
+			return m, func() tea.Msg { return CancelMsg{ID: id} }
+		case "ctrl+s":
+			return m, m.submit()
+		case "tab":
+			return m, m.setFocus(m.focus + 1)
+		case "shift+tab":
+			return m, m.setFocus(m.focus - 1)
+		case "down":
+			if !multiline {
+				return m, m.setFocus(m.focus + 1)
+			}
+		case "up":
+			if !multiline {
+				return m, m.setFocus(m.focus - 1)
+			}
+		case "enter":
+			if !multiline {
+				if m.focus == len(m.inputs)-1 {
+					return m, m.submit()
+				}
+				return m, m.setFocus(m.focus + 1)
+			}
+		}
+	}
+
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	in := &m.inputs[m.focus]
+	if in.multiline {
+		in.area, cmd = in.area.Update(msg)
+	} else {
+		in.line, cmd = in.line.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) submit() tea.Cmd {
+	id := m.id
+	values := m.Values()
+	return func() tea.Msg { return SubmitMsg{ID: id, Values: values} }
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(style.FormTitle.Render(m.title))
+	b.WriteString("\n")
+
+	hasMultiline := false
+	for i, in := range m.inputs {
+		b.WriteString("\n")
+		label := style.FormLabel
+		if i == m.focus {
+			label = style.Focused
+		}
+		b.WriteString(label.Render(in.label))
+		b.WriteString("\n")
+		if in.multiline {
+			hasMultiline = true
+			b.WriteString(in.area.View())
+		} else {
+			b.WriteString(in.line.View())
+		}
+		b.WriteString("\n")
+	}
+
+	if m.err != "" {
+		b.WriteString("\n")
+		b.WriteString(style.Error.Render(m.err))
+		b.WriteString("\n")
+	}
+
+	hint := "enter next/submit • tab next • esc cancel"
+	if hasMultiline {
+		hint = "tab next field • ctrl+s submit • esc cancel"
+	}
+	b.WriteString("\n")
+	b.WriteString(style.KeyHelpDesc.Render(hint))
+
+	return style.Modal.Width(m.width).Render(b.String())
+}
@@ -10,6 +10,7 @@ import (
 
 type Model struct {
 	logo, logoColor, endpoint, version, keyHelp string
+	badges                                      []string
 	compact                                     bool
 	width                                       int
 }
@@ -24,18 +25,22 @@ func (m Model) View() string {
 		logoStyle = logoStyle.Foreground(lipgloss.Color(m.logoColor))
 	}
 	clusterUrl := style.ClusterUrl.Render(m.endpoint)
+	version := m.version
+	for _, badge := range m.badges {
+		version += " " + style.HeaderBadge.Render(badge)
+	}
 	if m.compact {
 		versionStyle := style.Regular.Padding(0, 2, 0, 0)
 		return lipgloss.JoinHorizontal(
 			lipgloss.Center,
 			logoStyle.Padding(0).Margin(0).Render("ETCD TUI"),
 			style.KeyHelp.Render(m.keyHelp),
-			versionStyle.Render(m.version),
+			versionStyle.Render(version),
 			clusterUrl,
 		) + "\n"
 	}
 	logo := logoStyle.Render(m.logo)
-	left := style.Header.Render(lipgloss.JoinVertical(lipgloss.Center, logo, m.version, clusterUrl))
+	left := style.Header.Render(lipgloss.JoinVertical(lipgloss.Center, logo, version, clusterUrl))
 
 	keyHelpLines := strings.Split(m.keyHelp, "\n")
 	var keyHelpRows []string
@@ -77,6 +82,11 @@ func (m *Model) ToggleCompact() {
 	m.compact = !m.compact
 }
 
+// SetBadges sets short status labels shown next to the version.
+func (m *Model) SetBadges(badges ...string) {
+	m.badges = badges
+}
+
 func (m *Model) SetEndpoint(endpoint string) {
 	m.endpoint = endpoint
 }
@@ -0,0 +1,222 @@
+package history
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/style"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// OpenMsg asks the parent to show Entry in the value pane.
+type OpenMsg struct {
+	Entry etcd.HistoryEntry
+}
+
+// DiffMsg asks the parent to compare two versions, Old being the earlier.
+type DiffMsg struct {
+	Old etcd.HistoryEntry
+	New etcd.HistoryEntry
+}
+
+// CloseMsg is sent when the user leaves the history panel.
+type CloseMsg struct{}
+
+// Model lists the versions of one key, newest first.
+type Model struct {
+	key       string
+	entries   []etcd.HistoryEntry
+	compacted bool
+	more      bool
+	loading   bool
+	err       error
+	cursor    int
+	offset    int
+	open      int64
+	marked    []int64
+	width     int
+	height    int
+}
+
+func New(key string) Model {
+	return Model{key: key, loading: true}
+}
+
+func (m Model) Key() string {
+	return m.key
+}
+
+func (m *Model) SetHistory(msg etcd.HistoryMsg) {
+	m.loading = false
+	m.err = msg.Err
+	m.entries = msg.Entries
+	m.compacted = msg.Compacted
+	m.more = msg.More
+	m.cursor = 0
+	m.offset = 0
+	m.marked = nil
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.scroll()
+}
+
+// SetOpen marks the version shown in the value pane.
+func (m *Model) SetOpen(revision int64) {
+	m.open = revision
+}
+
+// Entries returns the loaded versions, newest first.
+func (m Model) Entries() []etcd.HistoryEntry {
+	return m.entries
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	last := utils.Max(0, len(m.entries)-1)
+	switch keyMsg.String() {
+	case "esc", "q":
+		return m, func() tea.Msg { return CloseMsg{} }
+	case "up", "k":
+		m.cursor = utils.Max(0, m.cursor-1)
+	case "down", "j":
+		m.cursor = utils.Min(m.cursor+1, last)
+	case "g":
+		m.cursor = 0
+	case "G":
+		m.cursor = last
+	case "enter":
+		if m.cursor < len(m.entries) {
+			entry := m.entries[m.cursor]
+			return m, func() tea.Msg { return OpenMsg{Entry: entry} }
+		}
+	case " ":
+		m.toggleMark()
+	case "d":
+		if older, newer, ok := m.pair(); ok {
+			return m, func() tea.Msg { return DiffMsg{Old: older, New: newer} }
+		}
+	}
+	m.scroll()
+	return m, nil
+}
+
+// toggleMark marks or unmarks the version under the cursor. Only two versions
+// can be marked; marking a third drops the earliest mark.
+func (m *Model) toggleMark() {
+	if m.cursor >= len(m.entries) {
+		return
+	}
+	rev := m.entries[m.cursor].ModRevision
+	if i := slices.Index(m.marked, rev); i >= 0 {
+		m.marked = slices.Delete(m.marked, i, i+1)
+		return
+	}
+	if len(m.marked) == 2 {
+		m.marked = m.marked[1:]
+	}
+	m.marked = append(m.marked, rev)
+}
+
+// pair returns the two versions to compare: the two marked ones, or the
+// marked one and the one under the cursor.
+func (m Model) pair() (older, newer etcd.HistoryEntry, ok bool) {
+	revs := slices.Clone(m.marked)
+	if len(revs) == 1 && m.cursor < len(m.entries) && m.entries[m.cursor].ModRevision != revs[0] {
+		revs = append(revs, m.entries[m.cursor].ModRevision)
+	}
+	if len(revs) != 2 {
+		return older, newer, false
+	}
+	slices.Sort(revs)
+	for _, e := range m.entries {
+		switch e.ModRevision {
+		case revs[0]:
+			older = e
+		case revs[1]:
+			newer = e
+		}
+	}
+	return older, newer, true
+}
+
+// rows is the number of versions that fit below the title, column header,
+// footer and help.
+func (m Model) rows() int {
+	return utils.Max(1, m.height-5)
+}
+
+func (m *Model) scroll() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+m.rows() {
+		m.offset = m.cursor - m.rows() + 1
+	}
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(style.TableHeader.Render("History") + ": " + utils.Truncate(utils.SanitizeForTUI(m.key), utils.Max(10, m.width-12)))
+	b.WriteString("\n")
+
+	switch {
+	case m.loading:
+		b.WriteString("\nLoading history...")
+		return b.String()
+	case m.err != nil:
+		b.WriteString("\n" + style.Error.Render(m.err.Error()))
+		return b.String()
+	case len(m.entries) == 0:
+		b.WriteString("\nThe key does not exist.")
+		return b.String()
+	}
+
+	b.WriteString(style.RowNumber.Render(fmt.Sprintf("  %9s %7s  %s", "Revision", "Version", "Value")))
+	b.WriteString("\n")
+
+	end := utils.Min(len(m.entries), m.offset+m.rows())
+
+	for i := m.offset; i < end; i++ {
+		e := m.entries[i]
+		marker := "  "
+		if slices.Contains(m.marked, e.ModRevision) {
+			marker = " *"
+		}
+		line := fmt.Sprintf("%s%9d %7d  %s", marker, e.ModRevision, e.Version, e.ValuePreview)
+		line = utils.Truncate(line, utils.Max(10, m.width-2))
+		switch {
+		case i == m.cursor:
+			line = style.SelectedRow.Render(line)
+		case e.ModRevision == m.open:
+			line = style.Focused.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString(style.KeyHelpDesc.Render(m.footer()))
+	b.WriteString("\n")
+	b.WriteString(style.KeyHelpDesc.Render("enter open • space mark • d diff • tab value pane • esc close"))
+	return b.String()
+}
+
+func (m Model) footer() string {
+	oldest := m.entries[len(m.entries)-1]
+	switch {
+	case m.compacted:
+		return fmt.Sprintf("%d versions; older revisions are compacted", len(m.entries))
+	case m.more:
+		return fmt.Sprintf("latest %d versions; older ones not loaded", len(m.entries))
+	}
+	return fmt.Sprintf("%d versions; created at revision %d", len(m.entries), oldest.CreateRevision)
+}
@@ -0,0 +1,295 @@
+package txn
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/style"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// Section is one of the three lists of a transaction.
+type Section int
+
+const (
+	Compares Section = iota
+	Success
+	Failure
+)
+
+func (s Section) String() string {
+	switch s {
+	case Success:
+		return "Then (success)"
+	case Failure:
+		return "Else (failure)"
+	default:
+		return "If (compares)"
+	}
+}
+
+// AddMsg asks the parent to collect a new item for Section.
+type AddMsg struct {
+	Section Section
+}
+
+// CommitMsg asks the parent to commit Spec.
+type CommitMsg struct {
+	Spec etcd.TxnSpec
+}
+
+// CloseMsg is sent when the user leaves the builder.
+type CloseMsg struct{}
+
+// Model composes a transaction. Keys picked from the table are offered as
+// defaults when adding items; once committed, the builder shows the result
+// until a key is pressed.
+type Model struct {
+	spec    etcd.TxnSpec
+	keys    []string
+	section Section
+	cursor  int
+	result  *etcd.TxnMsg
+	width   int
+	height  int
+}
+
+func New(keys []string) Model {
+	return Model{keys: keys}
+}
+
+func (m Model) Spec() etcd.TxnSpec {
+	return m.spec
+}
+
+// DefaultKey returns the key to prefill a new item with: the key of the item
+// under the cursor, or else the first picked key.
+func (m Model) DefaultKey() string {
+	switch items := m.items(m.section); {
+	case m.cursor < len(items):
+		return items[m.cursor]
+	case len(m.keys) > 0:
+		return m.keys[0]
+	}
+	return ""
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) AddCompare(c etcd.TxnCompare) {
+	m.spec.Compares = append(m.spec.Compares, c)
+	m.section = Compares
+	m.cursor = len(m.spec.Compares) - 1
+}
+
+// AddOp appends op to the branch that has focus.
+func (m *Model) AddOp(op etcd.TxnOp) {
+	if m.section == Failure {
+		m.spec.Failure = append(m.spec.Failure, op)
+		m.cursor = len(m.spec.Failure) - 1
+		return
+	}
+	m.section = Success
+	m.spec.Success = append(m.spec.Success, op)
+	m.cursor = len(m.spec.Success) - 1
+}
+
+func (m *Model) SetResult(msg etcd.TxnMsg) {
+	m.result = &msg
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.result != nil {
+		if keyMsg.String() == "esc" || keyMsg.String() == "q" {
+			return m, func() tea.Msg { return CloseMsg{} }
+		}
+		m.result = nil
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q":
+		return m, func() tea.Msg { return CloseMsg{} }
+	case "tab":
+		m.focus((m.section + 1) % 3)
+	case "shift+tab":
+		m.focus((m.section + 2) % 3)
+	case "up", "k":
+		m.cursor = utils.Max(0, m.cursor-1)
+	case "down", "j":
+		m.cursor = utils.Min(m.cursor+1, utils.Max(0, len(m.items(m.section))-1))
+	case "a", "n":
+		section := m.section
+		return m, func() tea.Msg { return AddMsg{Section: section} }
+	case "d", "x", "backspace":
+		m.remove()
+	case "ctrl+s", "c":
+		if m.spec.Empty() {
+			return m, nil
+		}
+		spec := m.spec
+		return m, func() tea.Msg { return CommitMsg{Spec: spec} }
+	}
+	return m, nil
+}
+
+func (m *Model) focus(s Section) {
+	m.section = s
+	m.cursor = 0
+}
+
+func (m *Model) remove() {
+	switch m.section {
+	case Compares:
+		m.spec.Compares = removeAt(m.spec.Compares, m.cursor)
+	case Success:
+		m.spec.Success = removeAt(m.spec.Success, m.cursor)
+	case Failure:
+		m.spec.Failure = removeAt(m.spec.Failure, m.cursor)
+	}
+	m.cursor = utils.Min(m.cursor, utils.Max(0, len(m.items(m.section))-1))
+}
+
+func removeAt[T any](items []T, idx int) []T {
+	if idx < 0 || idx >= len(items) {
+		return items
+	}
+	return append(items[:idx:idx], items[idx+1:]...)
+}
+
+// items returns the keys of the items in a section, in order.
+func (m Model) items(s Section) []string {
+	var keys []string
+	switch s {
+	case Compares:
+		for _, c := range m.spec.Compares {
+			keys = append(keys, c.Key)
+		}
+	case Success:
+		for _, op := range m.spec.Success {
+			keys = append(keys, op.Key)
+		}
+	case Failure:
+		for _, op := range m.spec.Failure {
+			keys = append(keys, op.Key)
+		}
+	}
+	return keys
+}
+
+func (m Model) lines(s Section) []string {
+	var lines []string
+	switch s {
+	case Compares:
+		for _, c := range m.spec.Compares {
+			lines = append(lines, c.String())
+		}
+	case Success:
+		for _, op := range m.spec.Success {
+			lines = append(lines, op.String())
+		}
+	case Failure:
+		for _, op := range m.spec.Failure {
+			lines = append(lines, op.String())
+		}
+	}
+	return lines
+}
+
+func (m Model) View() string {
+	if m.result != nil {
+		return m.resultView()
+	}
+
+	var b strings.Builder
+	b.WriteString(style.FormTitle.Render("Transaction builder"))
+	if len(m.keys) > 0 {
+		b.WriteString(style.KeyHelpDesc.Render(fmt.Sprintf("  picked: %s", strings.Join(m.keys, ", "))))
+	}
+	b.WriteString("\n")
+
+	for _, s := range []Section{Compares, Success, Failure} {
+		b.WriteString("\n")
+		title := style.FormLabel
+		if s == m.section {
+			title = style.Focused
+		}
+		b.WriteString(title.Render(s.String()))
+		b.WriteString("\n")
+
+		lines := m.lines(s)
+		if len(lines) == 0 {
+			b.WriteString(style.KeyHelpDesc.Render("  (none)"))
+			b.WriteString("\n")
+			continue
+		}
+		for i, line := range lines {
+			line = utils.Truncate(utils.NormalizeForDisplay(line, m.width), utils.Max(10, m.width-4))
+			if s == m.section && i == m.cursor {
+				b.WriteString(style.SelectedRow.Render("> " + line))
+			} else {
+				b.WriteString("  " + line)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(style.KeyHelpDesc.Render("tab section • ↑/↓ move • a add • d remove • c commit • esc close"))
+	return b.String()
+}
+
+func (m Model) resultView() string {
+	r := m.result
+	var b strings.Builder
+	b.WriteString(style.FormTitle.Render("Transaction result"))
+	b.WriteString("\n\n")
+
+	if r.Err != nil {
+		b.WriteString(style.Error.Render("Not committed: " + r.Err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(style.KeyHelpDesc.Render("any key back to the builder • esc close"))
+		return b.String()
+	}
+
+	if r.Succeeded {
+		fmt.Fprintf(&b, "Compares held: %s ran at revision %d.\n", Success, r.Revision)
+	} else {
+		fmt.Fprintf(&b, "Compares failed: %s ran at revision %d.\n", Failure, r.Revision)
+	}
+
+	if len(r.Results) == 0 {
+		b.WriteString("\n" + style.KeyHelpDesc.Render("(no operations)") + "\n")
+	}
+	for _, res := range r.Results {
+		b.WriteString("\n" + style.Bold.Render(res.Op.String()) + "\n")
+		switch res.Op.Type {
+		case etcd.TxnGet:
+			if len(res.KVs) == 0 {
+				b.WriteString("  (not found)\n")
+			}
+			for _, kv := range res.KVs {
+				b.WriteString("  " + utils.Truncate(kv.Key+" = "+kv.ValuePreview, utils.Max(10, m.width-4)) + "\n")
+			}
+		case etcd.TxnPut:
+			b.WriteString("  ok\n")
+		case etcd.TxnDelete:
+			fmt.Fprintf(&b, "  deleted %d\n", res.Deleted)
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(style.KeyHelpDesc.Render("any key back to the builder • esc close"))
+	return b.String()
+}
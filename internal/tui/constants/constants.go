@@ -16,6 +16,17 @@ const (
 	SplitAdjustInc = 0.05
 )
 
+const (
+	DeleteSampleSize         = 10
+	BulkDeleteTypedThreshold = 20  // Above this many keys a bulk delete needs typed confirmation
+	UndoStackSize            = 50  // Oldest undo steps are dropped beyond this
+	HistoryLimit             = 100 // Versions of a key loaded by the history panel
+	ErrorHistorySize         = 50  // Oldest errors are dropped beyond this
+	LargestKeysDefault       = 20  // Keys listed by the largest keys report unless asked otherwise
+	LargestKeysMax           = 100
+	WatchLogSize             = 1000 // Oldest events are dropped from the watch log beyond this
+)
+
 const (
 	HeaderPadding  = 2 // Padding for headers, titles, and separator lines
 	ContentPadding = 4 // Padding for content wrapping and truncation
@@ -36,6 +47,7 @@ const (
 	KeyEsc   = "esc"
 	KeyUp    = "up"
 	KeyCtrlC = "ctrl+c"
+	KeyCtrlR = "ctrl+r"
 	KeyDown  = "down"
 	KeyLeft  = "left"
 	KeyRight = "right"
@@ -43,12 +55,37 @@ const (
 	KeyR     = "r"
 	KeyRCaps = "R"
 	KeyC     = "c"
+	KeyCCaps = "C"
+	KeyD     = "d"
+	KeyDCaps = "D"
+	KeyE     = "e"
+	KeyECaps = "E"
 	KeyY     = "y"
 	KeyG     = "g"
 	KeyGCaps = "G"
 	KeyH     = "h"
+	KeyHCaps = "H"
+	KeyI     = "i"
 	KeyJ     = "j"
 	KeyK     = "k"
 	KeyL     = "l"
+	KeyLCaps = "L"
+	KeyM     = "m"
+	KeyMCaps = "M"
+	KeyN     = "n"
+	KeyS     = "s"
+	KeySCaps = "S"
+	KeyU     = "u"
+	KeyUCaps = "U"
+	KeyV     = "v"
+	KeyVCaps = "V"
+	KeyW     = "w"
+	KeyWCaps = "W"
+	KeyX     = "x"
+	KeySpace = " "
+	KeyStar  = "*"
+	KeyT     = "t"
+	KeyTCaps = "T"
 	KeySlash = "/"
+	KeyAt    = "@"
 )
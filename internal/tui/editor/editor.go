@@ -0,0 +1,124 @@
+package editor
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FinishedMsg is sent once the editor process exits.
+type FinishedMsg struct {
+	Key      string
+	Original string
+	Value    string
+	Err      error
+}
+
+func (m FinishedMsg) Changed() bool {
+	return m.Value != m.Original
+}
+
+// Command returns the user's editor command split into program and args.
+func Command() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// Open writes value to a temporary file and suspends the TUI while the
+// user's editor runs on it.
+func Open(key, value string) tea.Cmd {
+	return open(key, value, value)
+}
+
+// Reopen opens the editor on an earlier edit of original, so the result is
+// still compared against the stored value.
+func Reopen(key, original, edited string) tea.Cmd {
+	return open(key, original, edited)
+}
+
+// ReadyMsg is sent once the value is in a temporary file. Exec then runs the
+// editor on it.
+type ReadyMsg struct {
+	key      string
+	original string
+	value    string
+	path     string
+}
+
+// open writes the file off the UI goroutine and leaves running the editor
+// to Exec, which needs the program to suspend the TUI.
+func open(key, original, value string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := writeTemp(original, value)
+		if err != nil {
+			return FinishedMsg{Key: key, Original: original, Err: err}
+		}
+		return ReadyMsg{key: key, original: original, value: value, path: path}
+	}
+}
+
+// writeTemp writes value to a new temporary file, named .json when original
+// is JSON so that editors pick the right syntax.
+func writeTemp(original, value string) (string, error) {
+	pattern := "etcd-tui-*.txt"
+	if json.Valid([]byte(original)) {
+		pattern = "etcd-tui-*.json"
+	}
+
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+
+	if _, err := f.WriteString(value); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// Exec suspends the TUI while the user's editor runs on the file, then
+// reports what was saved.
+func (m ReadyMsg) Exec() tea.Cmd {
+	args := Command()
+	c := exec.Command(args[0], append(args[1:], m.path)...)
+
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		defer os.Remove(m.path)
+		if err != nil {
+			return FinishedMsg{Key: m.key, Original: m.original, Err: err}
+		}
+
+		data, err := os.ReadFile(m.path)
+		if err != nil {
+			return FinishedMsg{Key: m.key, Original: m.original, Err: err}
+		}
+
+		return FinishedMsg{Key: m.key, Original: m.original, Value: trimAddedNewline(m.value, string(data))}
+	})
+}
+
+// trimAddedNewline drops the trailing newline most editors append on save so
+// that an untouched value does not count as a change.
+func trimAddedNewline(original, edited string) string {
+	if strings.HasSuffix(original, "\n") {
+		return edited
+	}
+	if strings.HasSuffix(edited, "\r\n") {
+		return strings.TrimSuffix(edited, "\r\n")
+	}
+	return strings.TrimSuffix(edited, "\n")
+}
@@ -0,0 +1,95 @@
+package editor
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTrimAddedNewline(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		edited   string
+		expected string
+	}{
+		{"unchanged", "hello", "hello", "hello"},
+		{"editor appended newline", "hello", "hello\n", "hello"},
+		{"editor appended crlf", "hello", "hello\r\n", "hello"},
+		{"original had newline", "hello\n", "hello\n", "hello\n"},
+		{"only one newline trimmed", "hello", "hello\n\n", "hello\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := trimAddedNewline(tt.original, tt.edited)
+			if result != tt.expected {
+				t.Errorf("trimAddedNewline(%q, %q) = %q, want %q", tt.original, tt.edited, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		visual   string
+		editor   string
+		expected []string
+	}{
+		{"fallback", "", "", []string{"vi"}},
+		{"editor", "", "nano", []string{"nano"}},
+		{"visual wins", "code --wait", "nano", []string{"code", "--wait"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			result := Command()
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Command() = %v, want %v", result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("Command() = %v, want %v", result, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenWritesFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      func() any
+		suffix   string
+		expected string
+	}{
+		{"text", func() any { return Open("/k", "hello")() }, ".txt", "hello"},
+		{"json", func() any { return Open("/k", `{"a":1}`)() }, ".json", `{"a":1}`},
+		{"reopened edit", func() any { return Reopen("/k", `{"a":1}`, "not json")() }, ".json", "not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := tt.cmd()
+			msg, ok := sent.(ReadyMsg)
+			if !ok {
+				t.Fatalf("open sent %T, want ReadyMsg", sent)
+			}
+			defer os.Remove(msg.path)
+
+			if !strings.HasSuffix(msg.path, tt.suffix) {
+				t.Errorf("path = %q, want a %s file", msg.path, tt.suffix)
+			}
+			data, err := os.ReadFile(msg.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("file holds %q, want %q", data, tt.expected)
+			}
+		})
+	}
+}
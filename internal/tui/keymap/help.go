@@ -20,18 +20,20 @@ func GenerateKeyHelp(showValue bool) string {
 		return strings.TrimSpace(output)
 	}
 
-	firstRow := []string{"q/ctrl+c exit", "r refresh", "/ filter", "c copy"}
-	secondRow := []string{"↑/k up", "↓/j down", "g top", "G bottom"}
-	thirdRow := []string{"tab focus", "enter view", "esc back"}
+	firstRow := []string{"q/ctrl+c exit", "r refresh", "/ filter", "c copy", "i metadata", "@ revision", "L largest", "U usage", "W watch", "E errors"}
+	secondRow := []string{"↑/k up", "↓/j down", "g top", "G bottom", "s/S sort", "w live"}
+	thirdRow := []string{"tab focus", "enter view", "H history", "m compare", "esc back", "space select", "V range", "* all", "x export"}
+	writeRow := []string{"n new", "d delete", "D bulk delete", "M move", "C clone", "T txn", "u undo"}
 
 	var rows []string
 	rows = append(rows, getShortHelp(firstRow))
 	rows = append(rows, getShortHelp(secondRow))
 	rows = append(rows, getShortHelp(thirdRow))
+	rows = append(rows, getShortHelp(writeRow))
 
 	if showValue {
-		fourthRow := []string{"←/h shrink", "→/l expand"}
-		rows = append(rows, getShortHelp(fourthRow))
+		valueRow := []string{"←/h shrink", "→/l expand", "e edit", "t lease", "v diff"}
+		rows = append(rows, getShortHelp(valueRow))
 	}
 
 	return strings.Join(rows, "\n")
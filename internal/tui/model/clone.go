@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const (
+	formClone    = "clone"
+	confirmClone = "clone"
+)
+
+func (m Model) handleClone() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+
+	prefix := ""
+	if m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
+		prefix = utils.KeyPrefix(m.FilteredKeys[m.Cursor].Key)
+	}
+	return m.openForm(form.New(formClone, "Clone prefix",
+		form.Field{Label: "Source prefix", Value: prefix},
+		form.Field{Label: "Destination prefix", Value: prefix},
+	))
+}
+
+func (m Model) submitClone(values []string) (tea.Model, tea.Cmd) {
+	src, dst := values[0], values[1]
+	if strings.TrimSpace(src) == "" || strings.TrimSpace(dst) == "" {
+		m.Form.SetError("both prefixes are required")
+		return m, nil
+	}
+	if src == dst {
+		m.Form.SetError("source and destination are the same")
+		return m, nil
+	}
+	m.Form = nil
+	return m, m.EtcdRepo.PlanClone(src, dst)
+}
+
+func (m Model) handleClonePlanMsg(msg etcd.ClonePlanMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("clone %s: %w", msg.Source, msg.Err))
+		return m, nil
+	}
+	if msg.Count == 0 {
+		return m, (&m).flash("No keys under " + msg.Source)
+	}
+
+	m.PendingClone = msg
+	title := fmt.Sprintf("Clone %d keys from %s to %s?", msg.Count, msg.Source, msg.Dest)
+	if len(msg.Collisions) == 0 {
+		return m.openConfirm(confirm.New(confirmClone, title, "No destination keys exist yet."))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d destination keys already exist:\n", len(msg.Collisions))
+	for i, key := range msg.Collisions {
+		if i == 10 {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(msg.Collisions)-i)
+			break
+		}
+		b.WriteString("\n  " + key)
+	}
+	return m.openConfirm(confirm.New(confirmClone, title, b.String()).WithChoices("Skip", "Overwrite", "Abort"))
+}
+
+func (m Model) confirmClone(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	plan := m.PendingClone
+	m.PendingClone = etcd.ClonePlanMsg{}
+	if !msg.Confirmed {
+		return m, nil
+	}
+
+	mode := etcd.CollisionAbort
+	if msg.Choice != "Yes" {
+		var err error
+		mode, err = etcd.ParseCollisionMode(msg.Choice)
+		if err != nil || mode == etcd.CollisionAbort {
+			return m, nil
+		}
+	}
+	return m, m.EtcdRepo.ClonePrefix(plan.Source, plan.Dest, mode)
+}
+
+func (m Model) handleCloneMsg(msg etcd.CloneMsg) (tea.Model, tea.Cmd) {
+	m.pushUndo(msg.Changes)
+	m.noteOwnWrites(msg.Revisions...)
+	m.upsertKeys(msg.Copied...)
+	if m.TotalKeys >= 0 {
+		m.TotalKeys += len(msg.Copied) - msg.Overwritten
+	}
+	m.updateStatus()
+
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("clone stopped after %d keys: %w", len(msg.Copied), msg.Err))
+		return m, nil
+	}
+
+	m.setError(nil)
+	return m, (&m).flash(fmt.Sprintf("Cloned %d keys to %s (%d skipped)", len(msg.Copied), msg.Dest, msg.Skipped))
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/tui/view"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// compareHeaderLines counts each compare pane's title, metadata, banner and
+// separator.
+const compareHeaderLines = 4
+
+// handleCompareMark marks the row under the cursor for comparison. Pressing
+// it again on another row compares the two, and on the marked row unmarks it.
+func (m Model) handleCompareMark() (tea.Model, tea.Cmd) {
+	if !m.Connected || m.Cursor < 0 || m.Cursor >= len(m.FilteredKeys) {
+		return m, nil
+	}
+	name := m.FilteredKeys[m.Cursor].Name
+	switch m.CompareMark {
+	case "":
+		m.CompareMark = name
+		return m, (&m).flash("Comparing " + name + ": press m on another key")
+	case name:
+		m.CompareMark = ""
+		return m, (&m).flash("Comparison cancelled")
+	}
+	return m, m.EtcdRepo.CompareKeys(m.CompareMark, name)
+}
+
+func (m Model) handleCompareMsg(msg etcd.CompareMsg) (tea.Model, tea.Cmd) {
+	m.CompareMark = ""
+	if msg.Err != nil {
+		m.setError(msg.Err)
+		return m, nil
+	}
+
+	left, right := msg.Left.Value, msg.Right.Value
+	if formatted, ok := utils.FormatJSON(left); ok {
+		if formattedRight, ok := utils.FormatJSON(right); ok {
+			left, right = formatted, formattedRight
+		}
+	}
+	m.Compare = &msg
+	m.CompareLeft, m.CompareRight = utils.SideBySide(left, right)
+	m.CompareDiff, m.CompareByPath = compareDiff(msg.Left, msg.Right)
+	m.CompareShowDiff = false
+	m.CompareViewport = 0
+	return m, nil
+}
+
+// compareDiff lists the differences between the two values, by JSON path
+// when both are JSON documents and line by line otherwise.
+func compareDiff(left, right etcd.KeyValue) (diff []string, byPath bool) {
+	if lines, ok := utils.JSONDiff(left.Value, right.Value); ok {
+		return lines, true
+	}
+	lines := utils.UnifiedDiff(left.Value, right.Value, 3)
+	if len(lines) > 0 {
+		lines = append([]string{"--- " + left.Key, "+++ " + right.Key}, lines...)
+	}
+	return lines, false
+}
+
+func (m Model) handleCompareKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case constants.KeyCtrlC:
+		return m.handleQuit()
+	case constants.KeyEsc, constants.KeyQ, constants.KeyM:
+		m.closeCompare()
+	case constants.KeyD:
+		m.CompareShowDiff = !m.CompareShowDiff
+		m.CompareViewport = 0
+	case constants.KeyUp, constants.KeyK:
+		m.scrollCompare(-1)
+	case constants.KeyDown, constants.KeyJ:
+		m.scrollCompare(1)
+	case constants.KeyG:
+		m.CompareViewport = 0
+	case constants.KeyGCaps:
+		m.scrollCompare(m.compareLines())
+	case constants.KeyLeft, constants.KeyH:
+		m.adjustSplit(-constants.SplitAdjustInc)
+	case constants.KeyRight, constants.KeyL:
+		m.adjustSplit(constants.SplitAdjustInc)
+	}
+	return m, nil
+}
+
+func (m *Model) scrollCompare(delta int) {
+	used := len(strings.Split(m.Header.View(), "\n")) + len(strings.Split(m.Filter.View(), "\n"))
+	visible := utils.Max(1, m.Height-used-compareHeaderLines-1)
+	maxViewport := utils.Max(0, m.compareLines()-visible)
+	m.CompareViewport = utils.Clamp(m.CompareViewport+delta, 0, maxViewport)
+}
+
+// compareLines is the number of lines in the comparison being shown.
+func (m Model) compareLines() int {
+	if m.CompareShowDiff {
+		return len(m.CompareDiff)
+	}
+	return len(m.CompareLeft)
+}
+
+func (m *Model) closeCompare() {
+	m.Compare = nil
+	m.CompareLeft, m.CompareRight = nil, nil
+	m.CompareDiff, m.CompareByPath, m.CompareShowDiff = nil, false, false
+	m.CompareViewport = 0
+}
+
+// compareSummary says how many paths, or lines, of the comparison differ.
+func (m Model) compareSummary() string {
+	if m.CompareByPath {
+		switch len(m.CompareDiff) {
+		case 0:
+			return "The values are identical"
+		case 1:
+			return "1 path differs"
+		}
+		return fmt.Sprintf("%d paths differ", len(m.CompareDiff))
+	}
+
+	changed := 0
+	for i := range m.CompareLeft {
+		if m.CompareLeft[i] != m.CompareRight[i] {
+			changed++
+		}
+	}
+	if changed == 0 {
+		return "The values are identical"
+	}
+	if changed == 1 {
+		return "1 line differs"
+	}
+	return fmt.Sprintf("%d lines differ", changed)
+}
+
+// renderCompare shows the two values in the split layout, one per pane, or
+// the list of differences across the whole width.
+func (m Model) renderCompare(contentHeight int) string {
+	summary := fmt.Sprintf("Revision %d · %s", m.Compare.Revision, m.compareSummary())
+	if m.CompareShowDiff {
+		diff := m.CompareDiff
+		if len(diff) == 0 {
+			diff = []string{"The values are identical."}
+		}
+		how := "line by line"
+		if m.CompareByPath {
+			how = "by JSON path"
+		}
+		return view.RenderValueView(view.ValueViewData{
+			SelectedKey:   m.Compare.Left.Key + " → " + m.Compare.Right.Key,
+			Notice:        summary + ", " + how + " · d side by side · esc closes",
+			Diff:          strings.Join(diff, "\n"),
+			ValueViewport: m.CompareViewport,
+			Width:         m.Width,
+			Height:        contentHeight,
+			SplitRatio:    m.SplitRatio,
+			PaneWidth:     m.Width,
+		})
+	}
+
+	pane := func(kv etcd.KeyValue, lines []string, notice string) view.ValueViewData {
+		return view.ValueViewData{
+			SelectedKey:    kv.Key,
+			CreateRevision: kv.CreateRevision,
+			ModRevision:    kv.ModRevision,
+			Version:        kv.Version,
+			Notice:         notice,
+			Diff:           strings.Join(lines, "\n"),
+			ValueViewport:  m.CompareViewport,
+			Width:          m.Width,
+			Height:         contentHeight,
+			SplitRatio:     m.SplitRatio,
+		}
+	}
+
+	left := pane(m.Compare.Left, m.CompareLeft, summary)
+	left.PaneWidth = view.TablePaneWidth(m.Width, m.SplitRatio)
+	right := pane(m.Compare.Right, m.CompareRight, "d differences · esc closes · ↑/↓ scroll both")
+
+	return view.RenderSplitView(view.RenderValueView(left), view.RenderValueView(right), m.Width, m.SplitRatio, m.DraggingSplit)
+}
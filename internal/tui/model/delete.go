@@ -0,0 +1,256 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const (
+	confirmDeleteKey    = "delete-key"
+	confirmDeletePrefix = "delete-prefix"
+	confirmDeleteKeys   = "delete-keys"
+	formDeletePrefix    = "delete-prefix"
+)
+
+func (m Model) handleDelete() (tea.Model, tea.Cmd) {
+	if !m.Connected || m.Cursor < 0 || m.Cursor >= len(m.FilteredKeys) {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+	if len(m.Marked) > 0 {
+		return m.handleDeleteMarked()
+	}
+
+	kv := m.FilteredKeys[m.Cursor]
+	preview := kv.Value
+	if preview == "" {
+		preview = "(empty value)"
+	} else {
+		preview = utils.NormalizeForDisplay(preview, 200)
+	}
+
+	body := fmt.Sprintf("Key:   %s\nValue: %s", kv.Key, preview)
+	m.PendingDelete = []string{kv.Name}
+	return m.openConfirm(confirm.New(confirmDeleteKey, "Delete key?", body))
+}
+
+// handleBulkDelete deletes everything the filter matches, or asks for a
+// prefix when no filter is set. Either way a dry run is shown first.
+func (m Model) handleBulkDelete() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+
+	if m.Filter.HasFilterText() {
+		if len(m.FilteredKeys) == 0 {
+			return m, nil
+		}
+		names := make([]string, len(m.FilteredKeys))
+		keys := make([]string, len(m.FilteredKeys))
+		for i, kv := range m.FilteredKeys {
+			names[i], keys[i] = kv.Name, kv.Key
+		}
+		m.PendingDelete = names
+
+		title := fmt.Sprintf("Delete %d keys matching %q?", len(keys), m.Filter.Value())
+		c := confirm.New(confirmDeleteKeys, title, dryRunBody(int64(len(keys)), keys))
+		if len(keys) > constants.BulkDeleteTypedThreshold {
+			c = c.WithTypedConfirmation(m.Filter.Value())
+		}
+		return m.openConfirm(c)
+	}
+
+	prefix := ""
+	if m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
+		prefix = utils.KeyPrefix(m.FilteredKeys[m.Cursor].Key)
+	}
+	return m.openForm(form.New(formDeletePrefix, "Delete prefix",
+		form.Field{Label: "Prefix", Value: prefix},
+	))
+}
+
+func (m Model) handlePrefixCountMsg(msg etcd.PrefixCountMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("dry run for %s: %w", msg.Prefix, msg.Err))
+		return m, nil
+	}
+	if msg.Count == 0 {
+		return m, (&m).flash("No keys under " + msg.Prefix)
+	}
+
+	m.PendingDeletePrefix = msg.Prefix
+	title := fmt.Sprintf("Delete %d keys under %s?", msg.Count, msg.Prefix)
+	c := confirm.New(confirmDeletePrefix, title, dryRunBody(msg.Count, msg.Sample))
+	if msg.Count > constants.BulkDeleteTypedThreshold {
+		c = c.WithTypedConfirmation(msg.Prefix)
+	}
+	return m.openConfirm(c)
+}
+
+func dryRunBody(count int64, keys []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dry run: %d keys will be deleted.\n", count)
+
+	shown := utils.Min(len(keys), constants.DeleteSampleSize)
+	for _, key := range keys[:shown] {
+		b.WriteString("\n  " + key)
+	}
+	if count > int64(shown) {
+		fmt.Fprintf(&b, "\n  ... and %d more", count-int64(shown))
+	}
+	return b.String()
+}
+
+func (m Model) openConfirm(c confirm.Model) (tea.Model, tea.Cmd) {
+	c.SetWidth(m.modalWidth())
+	m.Confirm = &c
+	return m, c.Init()
+}
+
+func (m Model) handleConfirmResult(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	m.Confirm = nil
+	switch msg.ID {
+	case confirmClone:
+		return m.confirmClone(msg)
+	case confirmInvalidJSON:
+		return m.confirmInvalidJSON(msg)
+	case confirmOverwrite:
+		return m.confirmOverwrite(msg)
+	case confirmErrorLog:
+		return m.confirmErrorLog(msg)
+	case confirmLargestKeys:
+		return m.confirmLargestKeys(msg)
+	case confirmUsage:
+		return m.confirmUsage(msg)
+	case confirmTxn:
+		if msg.Confirmed && m.Txn != nil {
+			return m, m.EtcdRepo.CommitTxn(m.Txn.Spec())
+		}
+		return m, nil
+	}
+
+	keys, prefix := m.PendingDelete, m.PendingDeletePrefix
+	m.PendingDelete = nil
+	m.PendingDeletePrefix = ""
+	if !msg.Confirmed {
+		return m, nil
+	}
+
+	switch msg.ID {
+	case confirmDeleteKey:
+		if len(keys) == 0 {
+			return m, nil
+		}
+		return m, m.EtcdRepo.DeleteKey(keys[0])
+	case confirmDeleteKeys:
+		return m, m.EtcdRepo.DeleteKeys(keys)
+	case confirmDeletePrefix:
+		return m, m.EtcdRepo.DeletePrefix(prefix)
+	}
+	return m, nil
+}
+
+func (m Model) handleDeleteMsg(msg etcd.DeleteMsg) (tea.Model, tea.Cmd) {
+	m.pushUndo(msg.Changes)
+	m.noteOwnWrites(msg.Revisions...)
+	// A key that was already gone has no business staying in the list, but
+	// only the keys this delete removed change the count.
+	m.removeKeys(slices.Concat(msg.Keys, msg.Missing))
+	if msg.Prefix != "" {
+		m.removePrefix(msg.Prefix)
+	}
+	if m.TotalKeys >= 0 {
+		m.TotalKeys = utils.Max(0, m.TotalKeys-int(msg.Deleted))
+	}
+	m.updateStatus()
+
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("delete failed after %d keys: %w", msg.Deleted, msg.Err))
+		return m, nil
+	}
+
+	m.setError(nil)
+	switch {
+	case len(msg.Missing) > 0:
+		return m, (&m).flash(fmt.Sprintf("Deleted %d keys, %d were already gone", msg.Deleted, len(msg.Missing)))
+	case len(msg.Keys) == 1:
+		return m, (&m).flash("Deleted " + msg.Keys[0])
+	}
+	return m, (&m).flash(fmt.Sprintf("Deleted %d keys", msg.Deleted))
+}
+
+func (m *Model) removePrefix(prefix string) {
+	var keys []string
+	for _, kv := range m.AllKeys {
+		if strings.HasPrefix(kv.Name, prefix) {
+			keys = append(keys, kv.Name)
+		}
+	}
+	for _, kv := range m.PreFilterAllKeys {
+		if strings.HasPrefix(kv.Name, prefix) {
+			keys = append(keys, kv.Name)
+		}
+	}
+	m.removeKeys(keys)
+}
+
+// removeKeys drops the keys stored as names from every key list and keeps
+// the cursor on the row that took the place of the first removed row above
+// it.
+func (m *Model) removeKeys(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	removed := make(map[string]bool, len(names))
+	for _, name := range names {
+		removed[name] = true
+	}
+
+	removedAbove := 0
+	for i := 0; i < m.Cursor && i < len(m.FilteredKeys); i++ {
+		if removed[m.FilteredKeys[i].Name] {
+			removedAbove++
+		}
+	}
+
+	for k := range removed {
+		delete(m.Marked, k)
+	}
+
+	m.AllKeys = withoutKeys(m.AllKeys, removed)
+	if m.FilterTriggered {
+		m.PreFilterAllKeys = withoutKeys(m.PreFilterAllKeys, removed)
+	}
+
+	if m.ShowValue && removed[m.SelectedName] {
+		m.clearValueView()
+		m.updateKeyHelp()
+	}
+
+	m.Cursor -= removedAbove
+	m.refreshFilteredKeys()
+}
+
+func withoutKeys(keys []etcd.KeyValue, removed map[string]bool) []etcd.KeyValue {
+	kept := make([]etcd.KeyValue, 0, len(keys))
+	for _, kv := range keys {
+		if !removed[kv.Name] {
+			kept = append(kept, kv)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+)
+
+// keysModel is a connected model listing one row per name, in order.
+func keysModel(names ...string) Model {
+	m := New()
+	m.Connected = true
+	m.HasMoreKeys = false
+	for i, name := range names {
+		m.AllKeys = append(m.AllKeys, etcd.KeyValue{Name: name, Key: name, ModRevision: int64(i + 1)})
+	}
+	m.FilteredKeys = append([]etcd.KeyValue(nil), m.AllKeys...)
+	m.TotalKeys = len(names)
+	return m
+}
+
+func rowNames(keys []etcd.KeyValue) []string {
+	var names []string
+	for _, kv := range keys {
+		names = append(names, kv.Name)
+	}
+	return names
+}
+
+func TestDeleteUsesStoredName(t *testing.T) {
+	m := keysModel("/a", "/caf\xc3\xa9")
+	m.AllKeys[1].Key = "/caf"
+	m.FilteredKeys[1].Key = "/caf"
+	m.Cursor = 1
+
+	result, _ := m.handleDelete()
+	m = result.(Model)
+	if !slices.Equal(m.PendingDelete, []string{"/caf\xc3\xa9"}) {
+		t.Fatalf("PendingDelete = %q, want the stored name", m.PendingDelete)
+	}
+
+	result, _ = m.handleDeleteMsg(etcd.DeleteMsg{Keys: m.PendingDelete, Deleted: 1})
+	m = result.(Model)
+	if got := rowNames(m.AllKeys); !slices.Equal(got, []string{"/a"}) {
+		t.Errorf("rows = %q, want [/a]", got)
+	}
+}
+
+func TestBulkDeleteUsesStoredNames(t *testing.T) {
+	m := keysModel("/a", "/caf\xc3\xa9/x", "/caf\xc3\xa9/y")
+	m.AllKeys[1].Key, m.AllKeys[2].Key = "/caf/x", "/caf/y"
+	m.Filter.Focus()
+	m.Filter, _ = m.Filter.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("caf")})
+	m.FilteredKeys = slices.Clone(m.AllKeys[1:])
+
+	result, _ := m.handleBulkDelete()
+	m = result.(Model)
+	want := []string{"/caf\xc3\xa9/x", "/caf\xc3\xa9/y"}
+	if !slices.Equal(m.PendingDelete, want) {
+		t.Fatalf("PendingDelete = %q, want %q", m.PendingDelete, want)
+	}
+
+	// The second key was deleted by someone else in the meantime: its row
+	// goes, but it was not counted again.
+	result, _ = m.handleDeleteMsg(etcd.DeleteMsg{Keys: want[:1], Missing: want[1:], Deleted: 1})
+	m = result.(Model)
+	if got := rowNames(m.AllKeys); !slices.Equal(got, []string{"/a"}) {
+		t.Errorf("rows = %q, want [/a]", got)
+	}
+	if m.TotalKeys != 2 {
+		t.Errorf("TotalKeys = %d, want 2", m.TotalKeys)
+	}
+}
+
+func TestRemoveKeysCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []string
+		cursor  int
+		remove  []string
+		want    int
+		wantKey string
+	}{
+		{"above the cursor", []string{"/a", "/b", "/c", "/d"}, 2, []string{"/a"}, 1, "/c"},
+		{"at the cursor", []string{"/a", "/b", "/c", "/d"}, 2, []string{"/c"}, 2, "/d"},
+		{"below the cursor", []string{"/a", "/b", "/c", "/d"}, 2, []string{"/d"}, 2, "/c"},
+		{"around the cursor", []string{"/a", "/b", "/c", "/d"}, 2, []string{"/a", "/c"}, 1, "/d"},
+		{"the last row", []string{"/a", "/b", "/c"}, 2, []string{"/c"}, 1, "/b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := keysModel(tt.rows...)
+			m.Cursor = tt.cursor
+			m.removeKeys(tt.remove)
+			if m.Cursor != tt.want {
+				t.Fatalf("Cursor = %d, want %d", m.Cursor, tt.want)
+			}
+			if got := m.FilteredKeys[m.Cursor].Name; got != tt.wantKey {
+				t.Errorf("cursor on %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestRemoveKeysEmptiesList(t *testing.T) {
+	m := keysModel("/a")
+	m.removeKeys([]string{"/a"})
+	if len(m.FilteredKeys) != 0 || m.Cursor != 0 {
+		t.Errorf("rows = %d, cursor = %d, want an empty list with the cursor at 0", len(m.FilteredKeys), m.Cursor)
+	}
+}
+
+func TestDryRunBody(t *testing.T) {
+	names := func(n int) []string {
+		keys := make([]string, n)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("/k/%02d", i)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name      string
+		count     int64
+		keys      []string
+		wantLines int
+		wantMore  string
+	}{
+		{"every key fits", 3, names(3), 3, ""},
+		{"a filter past the sample", 12, names(12), 10, "... and 2 more"},
+		{"a prefix count past its sample", 250, names(10), 10, "... and 240 more"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := dryRunBody(tt.count, tt.keys)
+			if !strings.HasPrefix(body, fmt.Sprintf("Dry run: %d keys will be deleted.", tt.count)) {
+				t.Errorf("body starts %q", strings.SplitN(body, "\n", 2)[0])
+			}
+			if got := strings.Count(body, "\n  /k/"); got != tt.wantLines {
+				t.Errorf("listed %d keys, want %d", got, tt.wantLines)
+			}
+			hasMore := strings.Contains(body, "more")
+			if tt.wantMore == "" && hasMore {
+				t.Errorf("body = %q, want no \"more\" line", body)
+			}
+			if tt.wantMore != "" && !strings.HasSuffix(body, "\n  "+tt.wantMore) {
+				t.Errorf("body = %q, want it to end with %q", body, tt.wantMore)
+			}
+		})
+	}
+}
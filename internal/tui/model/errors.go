@@ -0,0 +1,106 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/tui/style"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const confirmErrorLog = "error-log"
+
+// ErrorEntry is one error in the error log.
+type ErrorEntry struct {
+	Time time.Time
+	Err  error
+}
+
+// setError shows err and records it in the error log.
+func (m *Model) setError(err error) {
+	m.Error = err
+	m.CachedMaxVisibleRows = 0
+	if err == nil {
+		return
+	}
+	m.Errors = append(m.Errors, ErrorEntry{Time: time.Now(), Err: err})
+	if len(m.Errors) > constants.ErrorHistorySize {
+		m.Errors = m.Errors[len(m.Errors)-constants.ErrorHistorySize:]
+	}
+}
+
+// clearError hides the current error. It stays in the error log.
+func (m *Model) clearError() {
+	m.Error = nil
+	m.CachedMaxVisibleRows = 0
+}
+
+func recoveryLabel(recovery etcd.Recovery) string {
+	switch recovery {
+	case etcd.RecoverReconnect:
+		return "re-authenticate"
+	case etcd.RecoverNextEndpoint:
+		return "try the next endpoint"
+	case etcd.RecoverCompactRevision:
+		return "go to the oldest readable revision"
+	}
+	return ""
+}
+
+// handleRecover runs the recovery offered for the current error.
+func (m Model) handleRecover() (tea.Model, tea.Cmd) {
+	if m.Error == nil {
+		return m, nil
+	}
+	_, recovery := etcd.Explain(m.Error)
+	switch recovery {
+	case etcd.RecoverReconnect:
+		return m, tea.Batch((&m).flash("Reconnecting..."), m.EtcdRepo.Reconnect())
+	case etcd.RecoverNextEndpoint:
+		return m, tea.Batch((&m).flash("Trying the next endpoint..."), m.EtcdRepo.UseNextEndpoint())
+	case etcd.RecoverCompactRevision:
+		var compacted *etcd.CompactedError
+		if errors.As(m.Error, &compacted) {
+			return m, m.EtcdRepo.PinRevision(compacted.CompactRevision)
+		}
+	}
+	return m, nil
+}
+
+// handleErrorLog lists recent errors, newest first, as many as fit.
+func (m Model) handleErrorLog() (tea.Model, tea.Cmd) {
+	if len(m.Errors) == 0 {
+		return m, (&m).flash("No errors so far")
+	}
+
+	limit := max(1, m.Height-16)
+	var b strings.Builder
+	for i := len(m.Errors) - 1; i >= 0 && len(m.Errors)-i <= limit; i-- {
+		e := m.Errors[i]
+		fmt.Fprintf(&b, "%s  %s\n", e.Time.Format("15:04:05"), utils.SanitizeForTUI(e.Err.Error()))
+		if hint, _ := etcd.Explain(e.Err); hint != "" {
+			fmt.Fprintf(&b, "          %s\n", style.KeyHelpDesc.Render(hint))
+		}
+	}
+	if older := len(m.Errors) - limit; older > 0 {
+		fmt.Fprintf(&b, "… %d older\n", older)
+	}
+
+	title := fmt.Sprintf("Errors (%d)", len(m.Errors))
+	return m.openConfirm(confirm.New(confirmErrorLog, title, strings.TrimRight(b.String(), "\n")).WithChoices("Clear", "Back"))
+}
+
+func (m Model) confirmErrorLog(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Choice == "Clear" {
+		m.Errors = nil
+		m.clearError()
+	}
+	return m, nil
+}
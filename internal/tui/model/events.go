@@ -0,0 +1,197 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/events"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/tui/view"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const formWatchEvents = "watch-events"
+
+// handleWatchEvents asks for the prefix to stream events for, starting from
+// the prefix of the row under the cursor.
+func (m Model) handleWatchEvents() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	prefix := ""
+	if m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
+		prefix = utils.KeyPrefix(m.FilteredKeys[m.Cursor].Key)
+	}
+	return m.openForm(form.New(formWatchEvents, "Watch events",
+		form.Field{Label: "Prefix", Value: prefix, Placeholder: "blank for the whole keyspace"},
+	))
+}
+
+func (m Model) submitWatchEvents(values []string) (tea.Model, tea.Cmd) {
+	w := m.EtcdRepo.WatchEvents(values[0], 0)
+	if w == nil {
+		m.Form.SetError("events cannot be watched while viewing a past revision")
+		return m, nil
+	}
+	m.Form = nil
+	log := events.New(values[0], constants.WatchLogSize)
+	m.Events = &log
+	m.EventWatch = w
+	m.Focus = constants.FocusTable
+	return m, w.Next()
+}
+
+func (m Model) handleEventWatchMsg(msg etcd.WatchMsg) (tea.Model, tea.Cmd) {
+	if msg.Closed || msg.Err != nil {
+		m.EventWatch.Stop()
+		m.EventWatch = nil
+		m.Events.SetErr(msg.Err)
+		return m, nil
+	}
+	m.Events.SetWatching(msg.Revision)
+	m.Events.Append(msg.Events, time.Now())
+	return m, m.EventWatch.Next()
+}
+
+// handleEventRestart watches again from the last revision the log saw, so
+// that no event is missed while the watch was down.
+func (m Model) handleEventRestart(msg events.RestartMsg) (tea.Model, tea.Cmd) {
+	if m.Events == nil {
+		return m, nil
+	}
+	m.EventWatch.Stop()
+	m.EventWatch = m.EtcdRepo.WatchEvents(msg.Prefix, msg.Revision)
+	if m.EventWatch == nil {
+		m.Events.SetErr(etcd.ErrHistorical)
+		return m, nil
+	}
+	return m, m.EventWatch.Next()
+}
+
+// handleEventsKey drives the event log. With the details pane focused only
+// scrolling is available.
+func (m Model) handleEventsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == constants.KeyCtrlC {
+		return m.handleQuit()
+	}
+	if !m.Events.Filtering() && m.EventDetail != nil {
+		switch {
+		case key == constants.KeyTab:
+			if m.Focus == constants.FocusValue {
+				m.Focus = constants.FocusTable
+			} else {
+				m.Focus = constants.FocusValue
+			}
+			return m, nil
+		case m.Focus == constants.FocusValue:
+			switch key {
+			case constants.KeyEsc:
+				m.Focus = constants.FocusTable
+			case constants.KeyUp, constants.KeyK:
+				m.scrollEventDetail(-1)
+			case constants.KeyDown, constants.KeyJ:
+				m.scrollEventDetail(1)
+			case constants.KeyG:
+				m.EventViewport = 0
+			case constants.KeyGCaps:
+				m.scrollEventDetail(strings.Count(m.EventDiff, "\n") + 1)
+			case constants.KeyLeft, constants.KeyH:
+				m.adjustSplit(-constants.SplitAdjustInc)
+			case constants.KeyRight, constants.KeyL:
+				m.adjustSplit(constants.SplitAdjustInc)
+			}
+			return m, nil
+		}
+	}
+
+	e, cmd := m.Events.Update(msg)
+	m.Events = &e
+	return m, cmd
+}
+
+// handleEventOpen shows the value before and after the event.
+func (m Model) handleEventOpen(msg events.OpenMsg) (tea.Model, tea.Cmd) {
+	ev := msg.Entry.Event
+	before, after := "", ev.KV.Value
+	var prevRev int64
+	if ev.Prev != nil {
+		before, prevRev = ev.Prev.Value, ev.Prev.ModRevision
+	}
+	if ev.Deleted {
+		after = ""
+	}
+
+	var notice string
+	switch {
+	case ev.Deleted:
+		notice = fmt.Sprintf("Deleted at revision %d", ev.Revision)
+	case ev.Prev == nil:
+		notice = fmt.Sprintf("Created at revision %d", ev.Revision)
+	default:
+		notice = fmt.Sprintf("Revision %d → %d", prevRev, ev.Revision)
+	}
+
+	diff, byPath := revisionDiff(before, after, prevRev, ev.Revision)
+	if byPath {
+		notice += " by JSON path"
+	}
+	entry := msg.Entry
+	m.EventDetail = &entry
+	m.EventDiff = diff
+	m.EventNotice = notice + " · " + msg.Entry.Time.Format("15:04:05")
+	m.EventViewport = 0
+	return m, nil
+}
+
+func (m *Model) scrollEventDetail(delta int) {
+	used := len(strings.Split(m.Header.View(), "\n")) + len(strings.Split(m.Filter.View(), "\n"))
+	visible := utils.Max(1, m.Height-used-compareHeaderLines-1)
+	maxViewport := utils.Max(0, strings.Count(m.EventDiff, "\n")+1-visible)
+	m.EventViewport = utils.Clamp(m.EventViewport+delta, 0, maxViewport)
+}
+
+func (m *Model) closeEvents() {
+	m.EventWatch.Stop()
+	m.EventWatch = nil
+	m.Events = nil
+	m.EventDetail = nil
+	m.EventDiff, m.EventNotice = "", ""
+	m.EventViewport = 0
+	m.Focus = constants.FocusTable
+}
+
+// renderEvents shows the event log, with the selected event's values next
+// to it once one is opened.
+func (m Model) renderEvents(contentHeight int) string {
+	if m.EventDetail == nil {
+		m.Events.SetSize(m.Width, contentHeight)
+		return m.Events.View()
+	}
+
+	m.Events.SetSize(view.TablePaneWidth(m.Width, m.SplitRatio), contentHeight)
+	kv := m.EventDetail.Event.KV
+	if p := m.EventDetail.Event.Prev; m.EventDetail.Event.Deleted && p != nil {
+		kv = *p
+	}
+	details := view.RenderValueView(view.ValueViewData{
+		SelectedKey:    kv.Key,
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Version:        kv.Version,
+		Notice:         m.EventNotice,
+		Diff:           m.EventDiff,
+		ValueViewport:  m.EventViewport,
+		Focus:          view.FocusArea(m.Focus),
+		Width:          m.Width,
+		Height:         contentHeight,
+		SplitRatio:     m.SplitRatio,
+		DraggingSplit:  m.DraggingSplit,
+	})
+	return view.RenderSplitView(m.Events.View(), details, m.Width, m.SplitRatio, m.DraggingSplit)
+}
@@ -67,12 +67,25 @@ func (m *Model) applyFilter(triggerFetch bool) tea.Cmd {
 			m.AllKeys = m.PreFilterAllKeys
 			m.Cursor = m.PreFilterCursor
 			m.TableYOffset = m.PreFilterYOffset
-			m.LastFetchedKey = m.PreFilterLastFetchedKey
+			m.NextPage = m.PreFilterNextPage
 			m.HasMoreKeys = m.PreFilterHasMoreKeys
 
 			m.PreFilterAllKeys = nil
 			m.FilterTriggered = false
 
+			if m.PreFilterSort != m.Sort {
+				// The sort changed while filtering, so the saved pages are
+				// in the old order.
+				m.AllKeys = []etcd.KeyValue{}
+				m.FilteredKeys = []etcd.KeyValue{}
+				m.Cursor = 0
+				m.TableYOffset = 0
+				m.NextPage = etcd.PageCursor{}
+				m.HasMoreKeys = true
+				m.FetchingKeys = true
+				return m.EtcdRepo.FetchKeys(m.Sort, m.NextPage, 100)
+			}
+
 			m.FilteredKeys = make([]etcd.KeyValue, len(m.AllKeys))
 			copy(m.FilteredKeys, m.AllKeys)
 
@@ -97,8 +110,9 @@ func (m *Model) applyFilter(triggerFetch bool) tea.Cmd {
 		copy(m.PreFilterAllKeys, m.AllKeys)
 		m.PreFilterCursor = m.Cursor
 		m.PreFilterYOffset = m.TableYOffset
-		m.PreFilterLastFetchedKey = m.LastFetchedKey
+		m.PreFilterNextPage = m.NextPage
 		m.PreFilterHasMoreKeys = m.HasMoreKeys
+		m.PreFilterSort = m.Sort
 
 		m.FilterTriggered = true
 	}
@@ -170,13 +184,47 @@ func (m *Model) fixTableViewport() {
 	m.TableYOffset = utils.Clamp(m.TableYOffset, 0, maxYOffset)
 }
 
-func (m *Model) scrollValue(delta int) {
-	displayValue := m.FormattedValue
-	if displayValue == "" {
-		displayValue = m.SelectedValue
+// displayedValue is the text the value pane is showing.
+func (m Model) displayedValue() string {
+	if m.PastDiff != "" {
+		return m.PastDiff
+	}
+	if m.PastValue != nil {
+		if m.PastFormatted != "" {
+			return m.PastFormatted
+		}
+		return m.PastValue.Value
+	}
+	if m.ShowDiff {
+		return m.DiffText
+	}
+	if m.FormattedValue != "" {
+		return m.FormattedValue
+	}
+	return m.SelectedValue
+}
+
+// valueHeaderLines counts the value pane's title, metadata, separator and
+// banner.
+func (m Model) valueHeaderLines() int {
+	switch {
+	case m.PastDiff != "":
+		return 3
+	case m.PastValue != nil:
+		return 4
+	}
+	lines := 2
+	if m.SelectedModRevision != 0 {
+		lines++
+	}
+	if m.ValueChange != nil {
+		lines++
 	}
+	return lines
+}
 
-	lines := strings.Split(displayValue, "\n")
+func (m *Model) scrollValue(delta int) {
+	lines := strings.Split(m.displayedValue(), "\n")
 	if len(lines) == 0 {
 		m.ValueViewport = 0
 		return
@@ -189,8 +237,7 @@ func (m *Model) scrollValue(delta int) {
 	usedHeight := headerLines + filterLines
 	availableHeight := utils.Max(1, m.Height-usedHeight)
 
-	valueHeaderLines := 2
-	availableContentHeight := utils.Max(1, availableHeight-valueHeaderLines)
+	availableContentHeight := utils.Max(1, availableHeight-m.valueHeaderLines())
 
 	needsFooter := len(lines) > availableContentHeight
 	maxVisibleLines := availableContentHeight
@@ -208,6 +255,24 @@ func (m *Model) updateKeyHelp() {
 	m.Header.SetKeyHelp(keymap.GenerateKeyHelp(m.ShowValue))
 }
 
+func (m *Model) updateBadges() {
+	var badges []string
+	if m.EtcdRepo.ReadOnly() {
+		badges = append(badges, "READ-ONLY")
+	}
+	if rev := m.EtcdRepo.Revision(); rev > 0 {
+		badges = append(badges, fmt.Sprintf("AT REVISION %d", rev))
+	}
+	if m.Live {
+		if m.LiveReconnecting {
+			badges = append(badges, "LIVE: RECONNECTING")
+		} else {
+			badges = append(badges, "LIVE")
+		}
+	}
+	m.Header.SetBadges(badges...)
+}
+
 func (m *Model) updateStatus() {
 	if m.CopyMessage != "" && time.Since(m.CopyMessageTime) < 2*time.Second {
 		m.Status = m.CopyMessage
@@ -227,6 +292,15 @@ func (m *Model) updateStatus() {
 	if m.Filter.HasFilterText() {
 		m.Status += fmt.Sprintf(" filtered: %d", len(m.FilteredKeys))
 	}
+	if m.Sort != (etcd.KeySort{}) {
+		m.Status += " sorted by " + m.Sort.String()
+	}
+	if m.CompareMark != "" {
+		m.Status += " comparing: " + utils.SanitizeForTUI(m.CompareMark)
+	}
+	if len(m.Marked) > 0 {
+		m.Status += fmt.Sprintf(" selected: %d", len(m.Marked))
+	}
 
 	m.Filter.SetPrefix(m.Status)
 }
@@ -238,20 +312,25 @@ func (m *Model) adjustSplit(delta float64) {
 
 func (m *Model) clearValueView() {
 	m.SelectedKey = ""
+	m.SelectedName = ""
 	m.SelectedValue = ""
+	m.SelectedRaw = ""
+	m.SelectedCreateRevision = 0
+	m.SelectedModRevision = 0
+	m.SelectedVersion = 0
+	m.SelectedLease = 0
+	m.SelectedLeaseTTL = 0
+	m.ValueLoading = false
 	m.FormattedValue = ""
 	m.IsJSON = false
 	m.ShowValue = false
 	m.ValueViewport = 0
 	m.Focus = constants.FocusTable
+	m.stopValueWatch()
 }
 
 func (m *Model) jumpToBottomOfValue() {
-	displayValue := m.FormattedValue
-	if displayValue == "" {
-		displayValue = m.SelectedValue
-	}
-	lines := strings.Split(displayValue, "\n")
+	lines := strings.Split(m.displayedValue(), "\n")
 	if len(lines) == 0 {
 		m.ValueViewport = 0
 		return
@@ -264,8 +343,7 @@ func (m *Model) jumpToBottomOfValue() {
 	usedHeight := headerLines + filterLines
 	availableHeight := utils.Max(1, m.Height-usedHeight)
 
-	valueHeaderLines := 2
-	availableContentHeight := utils.Max(1, availableHeight-valueHeaderLines)
+	availableContentHeight := utils.Max(1, availableHeight-m.valueHeaderLines())
 
 	needsFooter := len(lines) > availableContentHeight
 	maxVisibleLines := availableContentHeight
@@ -316,6 +394,11 @@ func (m Model) handleEscape() (tea.Model, tea.Cmd) {
 	if m.ShowValue {
 		m.clearValueView()
 		m.updateKeyHelp()
+		return m, nil
+	}
+	if m.MarkingRange || len(m.Marked) > 0 {
+		m.clearMarks()
+		m.updateStatus()
 	}
 	return m, nil
 }
@@ -327,16 +410,31 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	if m.Focus == constants.FocusTable && m.Connected && len(m.FilteredKeys) > 0 && m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
-		m.SelectedKey = m.FilteredKeys[m.Cursor].Key
-		m.ShowValue = true
-		m.ValueViewport = 0
+		cmd := m.openValue(m.FilteredKeys[m.Cursor].Name)
 		m.Focus = constants.FocusValue
 		m.updateKeyHelp()
-		return m, m.EtcdRepo.FetchValue(m.SelectedKey)
+		return m, cmd
+	}
+	if m.Focus == constants.FocusValue && m.ShowValue {
+		return m.handleReloadValue()
 	}
 	return m, nil
 }
 
+// openValue shows the key stored as name in the value pane and starts
+// loading it.
+func (m *Model) openValue(name string) tea.Cmd {
+	m.stopValueWatch()
+	m.SelectedName = name
+	m.SelectedKey = utils.SanitizeForTUI(name)
+	m.SelectedRaw = ""
+	m.SelectedModRevision = 0
+	m.ValueLoading = true
+	m.ShowValue = true
+	m.ValueViewport = 0
+	return m.EtcdRepo.FetchValue(name)
+}
+
 func (m Model) handleTab() (tea.Model, tea.Cmd) {
 	if m.ShowValue {
 		if m.Focus == constants.FocusTable {
@@ -398,11 +496,11 @@ func (m Model) handleRefresh() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	m.AllKeys = []etcd.KeyValue{}
-	m.LastFetchedKey = ""
+	m.NextPage = etcd.PageCursor{}
 	m.HasMoreKeys = true
 	m.TotalKeys = -1
 	return m, tea.Batch(
-		m.EtcdRepo.FetchKeys("", 100),
+		m.EtcdRepo.FetchKeys(m.Sort, etcd.PageCursor{}, 100),
 		m.EtcdRepo.FetchTotalCount(),
 	)
 }
@@ -471,7 +569,63 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case constants.KeyRight, constants.KeyL:
 		return m.handleSplitAdjust(constants.SplitAdjustInc)
 	case constants.KeyC, constants.KeyY:
+		if len(m.Marked) > 0 {
+			return m.handleCopyMarked(msg.String() == constants.KeyY)
+		}
 		return m.handleCopy()
+	case constants.KeyE:
+		return m.handleEdit()
+	case constants.KeyT:
+		return m.handleLease()
+	case constants.KeyV:
+		return m.handleToggleDiff()
+	case constants.KeyTCaps:
+		return m.handleOpenTxn()
+	case constants.KeyI:
+		m.ShowMeta = !m.ShowMeta
+		return m, nil
+	case constants.KeyHCaps:
+		return m.handleOpenHistory()
+	case constants.KeyAt:
+		return m.handleGoToRevision()
+	case constants.KeyS:
+		return m.handleSort(false)
+	case constants.KeySCaps:
+		return m.handleSort(true)
+	case constants.KeyLCaps:
+		return m.handleLargestKeys()
+	case constants.KeyUCaps:
+		return m.handleUsage()
+	case constants.KeyECaps:
+		return m.handleErrorLog()
+	case constants.KeyCtrlR:
+		return m.handleRecover()
+	case constants.KeyN:
+		return m.handleNewKey()
+	case constants.KeyD:
+		return m.handleDelete()
+	case constants.KeyDCaps:
+		return m.handleBulkDelete()
+	case constants.KeyM:
+		return m.handleCompareMark()
+	case constants.KeyMCaps:
+		return m.handleRename()
+	case constants.KeyCCaps:
+		return m.handleClone()
+	case constants.KeyU:
+		return m.handleUndo()
+	case constants.KeySpace:
+		return m.handleToggleMark()
+	case constants.KeyVCaps:
+		return m.handleMarkRange()
+	case constants.KeyStar:
+		return m.handleMarkAll()
+	case constants.KeyW:
+		return m.handleLive()
+	case constants.KeyWCaps:
+		return m.handleWatchEvents()
+	case constants.KeyX:
+		return m.handleExport()
 	}
 	return m, nil
 }
@@ -490,6 +644,16 @@ func copyToClipboard(text string) tea.Cmd {
 	}
 }
 
+func (m *Model) flash(message string) tea.Cmd {
+	m.CopyMessage = utils.SanitizeForTUI(message)
+	m.CopyMessageTime = time.Now()
+	m.updateStatus()
+	m.updateKeyHelp()
+	return tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+		return ClearCopyMsg{}
+	})
+}
+
 type CopyMsg struct {
 	Success bool
 	Message string
@@ -514,7 +678,9 @@ func (m Model) handleCopy() (tea.Model, tea.Cmd) {
 
 func (m Model) handleConnectionMsg(msg etcd.ConnectionMsg) (tea.Model, tea.Cmd) {
 	if msg.Success {
+		reconnected := m.Connected || m.Error != nil
 		m.EtcdRepo.SetClient(msg.Client)
+		m.clearError()
 		m.Connected = true
 		m.Status = "Connected"
 		m.Endpoint = config.GetEndpoints()
@@ -522,16 +688,23 @@ func (m Model) handleConnectionMsg(msg etcd.ConnectionMsg) (tea.Model, tea.Cmd)
 		m.Filter.SetPrefix(m.Status)
 		m.LastRefresh = time.Now()
 		m.AllKeys = []etcd.KeyValue{}
-		m.LastFetchedKey = ""
+		m.NextPage = etcd.PageCursor{}
 		m.HasMoreKeys = true
 		m.TotalKeys = -1
 		m.updateKeyHelp()
-		return m, tea.Batch(
-			m.EtcdRepo.FetchKeys("", 100),
+		cmds := []tea.Cmd{
+			m.EtcdRepo.FetchKeys(m.Sort, etcd.PageCursor{}, 100),
 			m.EtcdRepo.FetchTotalCount(),
-		)
+		}
+		if reconnected {
+			cmds = append(cmds, (&m).flash("Connected through "+msg.Endpoint))
+			if m.ShowValue && m.SelectedName != "" {
+				cmds = append(cmds, m.openValue(m.SelectedName))
+			}
+		}
+		return m, tea.Batch(cmds...)
 	}
-	m.Error = msg.Err
+	m.setError(msg.Err)
 	m.Status = "Connection Failed"
 	m.updateKeyHelp()
 	return m, nil
@@ -539,7 +712,7 @@ func (m Model) handleConnectionMsg(msg etcd.ConnectionMsg) (tea.Model, tea.Cmd)
 
 func (m Model) handleKeysMsg(msg etcd.KeysMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		m.Error = msg.Err
+		m.setError(msg.Err)
 		m.Status = "Error fetching keys"
 		m.updateKeyHelp()
 		return m, nil
@@ -548,6 +721,7 @@ func (m Model) handleKeysMsg(msg etcd.KeysMsg) (tea.Model, tea.Cmd) {
 	if m.FetchingAllKeys {
 		m.AllKeys = msg.Keys
 		m.FetchingAllKeys = false
+		etcd.SortKeys(m.AllKeys, m.Sort)
 
 		if m.FilterTriggered {
 			m.FilteredKeys = m.filterKeys()
@@ -557,17 +731,25 @@ func (m Model) handleKeysMsg(msg etcd.KeysMsg) (tea.Model, tea.Cmd) {
 			m.fixTableViewport()
 		}
 	} else if !m.FilterTriggered {
+		if msg.Sort != m.Sort {
+			m.FetchingKeys = false
+			return m, nil
+		}
 		if len(msg.Keys) > 0 {
-			m.LastFetchedKey = msg.Keys[len(msg.Keys)-1].Key
+			if len(m.AllKeys) == 0 {
+				m.KeysRevision = msg.Revision
+				m.forgetOwnWrites(msg.Revision)
+			}
+			m.NextPage = msg.Next
 			m.HasMoreKeys = msg.HasMore
 
 			existingKeys := make(map[string]bool, len(m.AllKeys))
 			for _, kv := range m.AllKeys {
-				existingKeys[kv.Key] = true
+				existingKeys[kv.Name] = true
 			}
 
 			for _, kv := range msg.Keys {
-				if !existingKeys[kv.Key] {
+				if !existingKeys[kv.Name] {
 					m.AllKeys = append(m.AllKeys, kv)
 				}
 			}
@@ -608,16 +790,29 @@ func (m Model) handleKeysMsg(msg etcd.KeysMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m Model) handleValueMsg(msg etcd.ValueMsg) Model {
+func (m Model) handleValueMsg(msg etcd.ValueMsg) (Model, tea.Cmd) {
+	if msg.Key != m.SelectedName {
+		return m, nil
+	}
+	m.ValueLoading = false
 	if msg.Err != nil {
-		m.Error = msg.Err
-		return m
+		m.setError(msg.Err)
+		return m, nil
 	}
 	trimmedValue := strings.TrimSpace(msg.Value)
 	m.SelectedValue = trimmedValue
+	m.SelectedRaw = msg.Raw
+	m.SelectedCreateRevision = msg.CreateRevision
+	m.SelectedModRevision = msg.ModRevision
+	m.SelectedVersion = msg.Version
+	m.SelectedLease = msg.Lease
+	m.SelectedLeaseTTL = msg.LeaseTTL
+	if m.History != nil && m.PastValue == nil {
+		m.History.SetOpen(msg.ModRevision)
+	}
 	formatted, isJSON := utils.FormatJSON(trimmedValue)
 	m.FormattedValue = formatted
 	m.IsJSON = isJSON
 	m.ValueViewport = 0
-	return m
+	return m, m.watchSelected()
 }
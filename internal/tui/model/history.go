@@ -0,0 +1,136 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/history"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// handleOpenHistory lists the versions of the key under the cursor, or of
+// the open key when the value pane has focus, next to its value.
+func (m Model) handleOpenHistory() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+
+	key := m.SelectedName
+	if m.Focus == constants.FocusTable || !m.ShowValue {
+		if m.Cursor < 0 || m.Cursor >= len(m.FilteredKeys) {
+			return m, nil
+		}
+		key = m.FilteredKeys[m.Cursor].Name
+	}
+
+	var cmds []tea.Cmd
+	if !m.ShowValue || m.SelectedName != key {
+		cmds = append(cmds, m.openValue(key))
+	}
+	h := history.New(key)
+	h.SetOpen(m.SelectedModRevision)
+	m.History = &h
+	m.Focus = constants.FocusTable
+	m.updateKeyHelp()
+	cmds = append(cmds, m.EtcdRepo.FetchHistory(key, constants.HistoryLimit))
+	return m, tea.Batch(cmds...)
+}
+
+// handleHistoryKey drives the history panel. With the value pane focused
+// only scrolling is available, so a past value cannot be edited by mistake.
+func (m Model) handleHistoryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); {
+	case key == constants.KeyCtrlC:
+		return m.handleQuit()
+	case key == constants.KeyTab:
+		return m.handleTab()
+	case m.Focus == constants.FocusValue:
+		switch key {
+		case constants.KeyEsc:
+			m.Focus = constants.FocusTable
+			m.updateKeyHelp()
+		case constants.KeyUp, constants.KeyK, constants.KeyDown, constants.KeyJ, constants.KeyG, constants.KeyGCaps:
+			return m.handleKey(msg)
+		}
+		return m, nil
+	}
+
+	h, cmd := m.History.Update(msg)
+	m.History = &h
+	return m, cmd
+}
+
+// handleHistoryOpen shows a past version in the value pane. Opening the
+// current version goes back to the live value.
+func (m Model) handleHistoryOpen(msg history.OpenMsg) (tea.Model, tea.Cmd) {
+	m.ValueViewport = 0
+	m.clearPast()
+	if msg.Entry.ModRevision == m.SelectedModRevision {
+		return m, nil
+	}
+
+	entry := msg.Entry
+	m.PastValue = &entry
+	m.PastFormatted, m.PastIsJSON = utils.FormatJSON(entry.Value)
+	m.History.SetOpen(entry.ModRevision)
+	return m, nil
+}
+
+// handleHistoryDiff shows the difference between two versions in the value
+// pane, by JSON path when both are JSON documents.
+func (m Model) handleHistoryDiff(msg history.DiffMsg) (tea.Model, tea.Cmd) {
+	m.clearPast()
+	m.ValueViewport = 0
+
+	before := utils.SanitizeForTUI(msg.Old.Raw)
+	after := utils.SanitizeForTUI(msg.New.Raw)
+	title := fmt.Sprintf("Revision %d → %d", msg.Old.ModRevision, msg.New.ModRevision)
+
+	diff, byPath := revisionDiff(before, after, msg.Old.ModRevision, msg.New.ModRevision)
+	if byPath {
+		title += " by JSON path"
+	}
+	m.PastDiff = diff
+	m.PastDiffTitle = title + " · esc closes history"
+	return m, nil
+}
+
+// revisionDiff diffs the values a key had at two revisions, by JSON path
+// when both are JSON documents and line by line otherwise.
+func revisionDiff(before, after string, oldRev, newRev int64) (diff string, byPath bool) {
+	lines, byPath := utils.JSONDiff(before, after)
+	if !byPath {
+		lines = utils.UnifiedDiff(before, after, 3)
+		if len(lines) > 0 {
+			lines = append([]string{
+				fmt.Sprintf("--- revision %d", oldRev),
+				fmt.Sprintf("+++ revision %d", newRev),
+			}, lines...)
+		}
+	}
+	if len(lines) == 0 {
+		lines = []string{"The values are identical."}
+	}
+	return strings.Join(lines, "\n"), byPath
+}
+
+func (m *Model) clearPast() {
+	m.PastValue = nil
+	m.PastFormatted = ""
+	m.PastIsJSON = false
+	m.PastDiff = ""
+	m.PastDiffTitle = ""
+	if m.History != nil {
+		m.History.SetOpen(m.SelectedModRevision)
+	}
+}
+
+func (m *Model) closeHistory() {
+	m.clearPast()
+	m.History = nil
+	m.ValueViewport = 0
+	m.updateKeyHelp()
+}
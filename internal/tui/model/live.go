@@ -0,0 +1,207 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// liveRetryDelay is how long live mode waits before watching again after
+// its watch failed.
+const liveRetryDelay = 2 * time.Second
+
+// LiveRetryMsg asks live mode to watch again after its watch failed.
+type LiveRetryMsg struct{}
+
+// handleLive turns live mode on or off. While it is on the whole keyspace is
+// watched from the revision the key list was read at, and every change is
+// applied to the loaded rows.
+func (m Model) handleLive() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	if m.Live {
+		m.stopLive()
+		return m, (&m).flash("Live mode off")
+	}
+	if m.EtcdRepo.Revision() > 0 {
+		return m, (&m).flash("Live mode follows the latest revision; press @ to return to it")
+	}
+
+	m.Live = true
+	m.LiveRevision = m.KeysRevision
+	cmd := m.startLive()
+	m.updateBadges()
+	return m, tea.Batch((&m).flash("Live mode on"), cmd)
+}
+
+// startLive watches from LiveRevision, or from now on when it is 0.
+func (m *Model) startLive() tea.Cmd {
+	m.LiveWatch.Stop()
+	if m.LiveRevision > 0 {
+		m.forgetOwnWrites(m.LiveRevision)
+	} else {
+		clear(m.OwnRevisions)
+	}
+	m.LiveWatch = m.EtcdRepo.WatchPrefix("", m.LiveRevision)
+	return m.LiveWatch.Next()
+}
+
+func (m *Model) stopLive() {
+	m.LiveWatch.Stop()
+	m.LiveWatch = nil
+	m.Live = false
+	m.LiveReconnecting = false
+	clear(m.LiveCounted)
+	m.updateBadges()
+}
+
+func (m Model) handleLiveWatchMsg(msg etcd.WatchMsg) (tea.Model, tea.Cmd) {
+	if msg.Closed || msg.Err != nil {
+		m.LiveWatch.Stop()
+		m.LiveWatch = nil
+		m.LiveReconnecting = true
+		m.updateBadges()
+		// Changes made before the compaction cannot be replayed, so the
+		// next watch starts from now and the list is read again.
+		var compacted *etcd.CompactedError
+		if errors.As(msg.Err, &compacted) {
+			m.LiveRevision = 0
+		}
+		return m, tea.Tick(liveRetryDelay, func(time.Time) tea.Msg { return LiveRetryMsg{} })
+	}
+
+	if m.LiveReconnecting {
+		m.LiveReconnecting = false
+		m.updateBadges()
+	}
+	m.applyLiveEvents(msg.Events)
+	if msg.Revision > m.LiveRevision {
+		m.LiveRevision = msg.Revision
+	}
+	return m, m.LiveWatch.Next()
+}
+
+func (m Model) handleLiveRetry() (tea.Model, tea.Cmd) {
+	if !m.Live || m.LiveWatch != nil || !m.Connected {
+		return m, nil
+	}
+	if m.LiveRevision > 0 || m.FilterTriggered {
+		return m, m.startLive()
+	}
+	cmd := m.startLive()
+	result, refresh := m.handleRefresh()
+	return result, tea.Batch(cmd, refresh)
+}
+
+// applyLiveEvents applies watched puts and deletes to the loaded rows and
+// re-applies the filter, keeping the cursor on the same key.
+func (m *Model) applyLiveEvents(events []etcd.WatchEvent) {
+	if len(events) == 0 {
+		return
+	}
+	current := ""
+	if m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
+		current = m.FilteredKeys[m.Cursor].Name
+	}
+
+	for _, ev := range events {
+		if ev.Deleted {
+			delete(m.Marked, ev.KV.Name)
+		}
+		m.AllKeys = liveApply(m.AllKeys, ev, m.Sort, m.FilterTriggered || !m.HasMoreKeys)
+		if m.FilterTriggered {
+			m.PreFilterAllKeys = liveApply(m.PreFilterAllKeys, ev, m.PreFilterSort, !m.PreFilterHasMoreKeys)
+		}
+		// The session's own writes were counted when they returned. Any
+		// other create or delete changes the count, loaded or not.
+		if !m.OwnRevisions[ev.Revision] {
+			m.countLiveEvent(ev)
+		}
+	}
+
+	last := events[len(events)-1].Revision
+	m.forgetOwnWrites(last)
+	for rev := range m.LiveCounted {
+		if rev < last-liveCountedWindow {
+			delete(m.LiveCounted, rev)
+		}
+	}
+
+	m.refreshFilteredKeys()
+	if current != "" {
+		m.moveCursorToKey(current)
+	}
+}
+
+// liveCountedWindow is how many revisions back live mode remembers what it
+// counted, for writes of the session whose reply is slower than the watch.
+const liveCountedWindow = 10000
+
+func (m *Model) countLiveEvent(ev etcd.WatchEvent) {
+	var delta int
+	switch {
+	case ev.Deleted:
+		delta = -1
+	case ev.KV.Version == 1:
+		delta = 1
+	}
+	m.LiveCounted[ev.Revision] += delta
+	if m.TotalKeys >= 0 {
+		m.TotalKeys = utils.Max(0, m.TotalKeys+delta)
+	}
+}
+
+// noteOwnWrites records the revisions a write of the session committed at,
+// so that live mode does not count their events again. When the watch got
+// there first, what it counted is taken back, since the write's handler
+// adjusts the count itself.
+func (m *Model) noteOwnWrites(revs ...int64) {
+	for _, rev := range revs {
+		if delta, ok := m.LiveCounted[rev]; ok {
+			delete(m.LiveCounted, rev)
+			if m.TotalKeys >= 0 {
+				m.TotalKeys -= delta
+			}
+			continue
+		}
+		m.OwnRevisions[rev] = true
+	}
+}
+
+// forgetOwnWrites drops the own writes at or before rev, whose events have
+// been seen or are part of a list read at rev.
+func (m *Model) forgetOwnWrites(rev int64) {
+	for r := range m.OwnRevisions {
+		if r <= rev {
+			delete(m.OwnRevisions, r)
+		}
+	}
+}
+
+// liveApply applies ev to keys, which are in the order s. Events older than
+// the loaded row are dropped, since the row was read after them. When the
+// list is not complete, a key that sorts after the last loaded row is left
+// for the page that will load it.
+func liveApply(keys []etcd.KeyValue, ev etcd.WatchEvent, s etcd.KeySort, complete bool) []etcd.KeyValue {
+	i := slices.IndexFunc(keys, func(k etcd.KeyValue) bool { return k.Name == ev.KV.Name })
+	if i >= 0 {
+		if keys[i].ModRevision >= ev.Revision {
+			return keys
+		}
+		keys = slices.Delete(keys, i, i+1)
+	}
+	if ev.Deleted {
+		return keys
+	}
+	idx, _ := slices.BinarySearchFunc(keys, ev.KV, s.Compare)
+	if idx == len(keys) && !complete {
+		return keys
+	}
+	return slices.Insert(keys, idx, ev.KV)
+}
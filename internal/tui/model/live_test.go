@@ -0,0 +1,91 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+)
+
+func liveModel() Model {
+	m := New()
+	m.Connected = true
+	m.Live = true
+	m.HasMoreKeys = false
+	m.AllKeys = []etcd.KeyValue{{Name: "/a", Key: "/a", ModRevision: 2, CreateRevision: 2, Version: 1}}
+	m.FilteredKeys = append([]etcd.KeyValue(nil), m.AllKeys...)
+	m.TotalKeys = 1
+	return m
+}
+
+func TestLiveEventsCountKeysOnce(t *testing.T) {
+	created := etcd.KeyValue{Name: "/b", Key: "/b", ModRevision: 5, CreateRevision: 5, Version: 1}
+	createdEvent := etcd.WatchEvent{Key: "/b", Revision: 5, KV: created}
+	deletedEvent := etcd.WatchEvent{Key: "/a", Revision: 6, Deleted: true, KV: etcd.KeyValue{Name: "/a", Key: "/a", ModRevision: 6}}
+	ownPut := etcd.PutMsg{Key: "/b", KV: created, Revision: 5, Created: true}
+	ownDelete := etcd.DeleteMsg{Keys: []string{"/a"}, Deleted: 1, Revisions: []int64{6}}
+
+	tests := []struct {
+		name       string
+		apply      func(Model) Model
+		wantTotal  int
+		wantLoaded int
+	}{
+		{"own put then its event", func(m Model) Model {
+			result, _ := m.handlePutMsg(ownPut)
+			m = result.(Model)
+			m.applyLiveEvents([]etcd.WatchEvent{createdEvent})
+			return m
+		}, 2, 2},
+		{"own put after its event", func(m Model) Model {
+			m.applyLiveEvents([]etcd.WatchEvent{createdEvent})
+			result, _ := m.handlePutMsg(ownPut)
+			return result.(Model)
+		}, 2, 2},
+		{"own delete then its event", func(m Model) Model {
+			result, _ := m.handleDeleteMsg(ownDelete)
+			m = result.(Model)
+			m.applyLiveEvents([]etcd.WatchEvent{deletedEvent})
+			return m
+		}, 0, 0},
+		{"own delete after its event", func(m Model) Model {
+			m.applyLiveEvents([]etcd.WatchEvent{deletedEvent})
+			result, _ := m.handleDeleteMsg(ownDelete)
+			return result.(Model)
+		}, 0, 0},
+		{"someone else's put", func(m Model) Model {
+			m.applyLiveEvents([]etcd.WatchEvent{createdEvent})
+			return m
+		}, 2, 2},
+		{"someone else's delete", func(m Model) Model {
+			m.applyLiveEvents([]etcd.WatchEvent{deletedEvent})
+			return m
+		}, 0, 0},
+		{"an update", func(m Model) Model {
+			m.applyLiveEvents([]etcd.WatchEvent{{Key: "/a", Revision: 7, KV: etcd.KeyValue{Name: "/a", Key: "/a", ModRevision: 7, CreateRevision: 2, Version: 2}}})
+			return m
+		}, 1, 1},
+		{"someone else's put past the last loaded row", func(m Model) Model {
+			m.HasMoreKeys = true
+			kv := etcd.KeyValue{Name: "/z", Key: "/z", ModRevision: 8, CreateRevision: 8, Version: 1}
+			m.applyLiveEvents([]etcd.WatchEvent{{Key: "/z", Revision: 8, KV: kv}})
+			return m
+		}, 2, 1},
+		{"someone else's delete of a row not loaded", func(m Model) Model {
+			m.HasMoreKeys = true
+			m.applyLiveEvents([]etcd.WatchEvent{{Key: "/z", Revision: 8, Deleted: true, KV: etcd.KeyValue{Name: "/z", Key: "/z", ModRevision: 8}}})
+			return m
+		}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.apply(liveModel())
+			if m.TotalKeys != tt.wantTotal {
+				t.Errorf("TotalKeys = %d, want %d", m.TotalKeys, tt.wantTotal)
+			}
+			if len(m.AllKeys) != tt.wantLoaded {
+				t.Errorf("loaded %d keys, want %d", len(m.AllKeys), tt.wantLoaded)
+			}
+		})
+	}
+}
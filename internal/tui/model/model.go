@@ -10,9 +10,15 @@ import (
 
 	"github.com/olamilekan000/etcd-tui/internal/config"
 	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/events"
 	"github.com/olamilekan000/etcd-tui/internal/tui/components/filter"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
 	"github.com/olamilekan000/etcd-tui/internal/tui/components/header"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/history"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/txn"
 	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/tui/editor"
 	"github.com/olamilekan000/etcd-tui/internal/tui/keymap"
 	"github.com/olamilekan000/etcd-tui/internal/tui/style"
 	"github.com/olamilekan000/etcd-tui/internal/tui/view"
@@ -23,19 +29,48 @@ type Model struct {
 	EtcdRepo  etcd.Repository
 	Connected bool
 	Error     error
+	Errors    []ErrorEntry
 	Endpoint  string
 
-	AllKeys        []etcd.KeyValue
-	FilteredKeys   []etcd.KeyValue
-	LastFetchedKey string
-	HasMoreKeys    bool
-	FetchingKeys   bool
-
-	Cursor        int
-	TableYOffset  int
-	SelectedKey   string
-	SelectedValue string
-	Focus         string
+	AllKeys      []etcd.KeyValue
+	FilteredKeys []etcd.KeyValue
+	NextPage     etcd.PageCursor
+	HasMoreKeys  bool
+	FetchingKeys bool
+	Sort         etcd.KeySort
+	KeysRevision int64
+
+	Live             bool
+	LiveWatch        *etcd.Watcher
+	LiveRevision     int64
+	LiveReconnecting bool
+	// OwnRevisions are the revisions this session wrote at whose events
+	// live mode has not seen yet, and LiveCounted what live mode added to
+	// TotalKeys for each recent revision it saw.
+	OwnRevisions map[int64]bool
+	LiveCounted  map[int64]int
+
+	Cursor                 int
+	TableYOffset           int
+	SelectedKey            string
+	SelectedName           string
+	SelectedValue          string
+	SelectedRaw            string
+	SelectedCreateRevision int64
+	SelectedModRevision    int64
+	SelectedVersion        int64
+	SelectedLease          int64
+	SelectedLeaseTTL       int64
+	Focus                  string
+	ShowMeta               bool
+
+	ValueLoading    bool
+	EditModRevision int64
+
+	ValueWatch  *etcd.Watcher
+	ValueChange *etcd.WatchEvent
+	ShowDiff    bool
+	DiffText    string
 
 	CachedMaxVisibleRows int
 	CachedHeight         int
@@ -45,11 +80,12 @@ type Model struct {
 	FetchingAllKeys bool
 	FilterTriggered bool
 
-	PreFilterAllKeys        []etcd.KeyValue
-	PreFilterCursor         int
-	PreFilterYOffset        int
-	PreFilterLastFetchedKey string
-	PreFilterHasMoreKeys    bool
+	PreFilterAllKeys     []etcd.KeyValue
+	PreFilterCursor      int
+	PreFilterYOffset     int
+	PreFilterNextPage    etcd.PageCursor
+	PreFilterHasMoreKeys bool
+	PreFilterSort        etcd.KeySort
 
 	ShowValue      bool
 	FormattedValue string
@@ -67,8 +103,52 @@ type Model struct {
 	CopyMessage     string
 	CopyMessageTime time.Time
 
-	Header header.Model
-	Filter filter.Model
+	Header  header.Model
+	Filter  filter.Model
+	Form    *form.Model
+	Confirm *confirm.Model
+	Txn     *txn.Model
+	History *history.Model
+
+	PastValue     *etcd.HistoryEntry
+	PastFormatted string
+	PastIsJSON    bool
+	PastDiff      string
+	PastDiffTitle string
+
+	CompareMark     string
+	Compare         *etcd.CompareMsg
+	CompareLeft     []string
+	CompareRight    []string
+	CompareViewport int
+	CompareDiff     []string
+	CompareByPath   bool
+	CompareShowDiff bool
+
+	Events        *events.Model
+	EventWatch    *etcd.Watcher
+	EventDetail   *events.Entry
+	EventDiff     string
+	EventNotice   string
+	EventViewport int
+
+	UsagePrefix    string
+	UsageDepth     int
+	UsageDelimiter string
+
+	PendingDelete       []string
+	PendingDeletePrefix string
+	PendingClone        etcd.ClonePlanMsg
+	PendingEdit         editor.FinishedMsg
+	PendingCreate       PendingCreate
+	PendingRename       etcd.KeyValue
+
+	UndoStack    [][]etcd.Change
+	UndoInFlight bool
+
+	Marked       map[string]bool
+	MarkingRange bool
+	MarkAnchor   int
 }
 
 func New() Model {
@@ -80,11 +160,10 @@ func New() Model {
 	status := "Connecting..."
 	keyHelp := "q r / tab ↑↓ g/G enter esc"
 
-	return Model{
+	m := Model{
 		EtcdRepo:         etcd.NewRepository(),
 		AllKeys:          []etcd.KeyValue{},
 		FilteredKeys:     []etcd.KeyValue{},
-		LastFetchedKey:   "",
 		HasMoreKeys:      true,
 		FetchingKeys:     false,
 		Connected:        false,
@@ -98,10 +177,16 @@ func New() Model {
 		FetchingAllKeys:  false,
 		FilterTriggered:  false,
 		PreFilterAllKeys: []etcd.KeyValue{},
+		UsageDepth:       1,
+		UsageDelimiter:   "/",
+		OwnRevisions:     make(map[int64]bool),
+		LiveCounted:      make(map[int64]int),
 
 		Header: header.New(constants.LogoString, "", endpoint, constants.Version, keyHelp),
 		Filter: filter.New(status),
 	}
+	m.updateBadges()
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -109,6 +194,66 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.Form != nil {
+		switch msg.(type) {
+		case tea.KeyMsg:
+			f, cmd := m.Form.Update(msg)
+			m.Form = &f
+			return m, cmd
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
+	if m.Confirm != nil {
+		switch msg.(type) {
+		case tea.KeyMsg:
+			c, cmd := m.Confirm.Update(msg)
+			m.Confirm = &c
+			return m, cmd
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
+	if m.Txn != nil {
+		switch msg.(type) {
+		case tea.KeyMsg:
+			t, cmd := m.Txn.Update(msg)
+			m.Txn = &t
+			return m, cmd
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
+	if m.History != nil {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return m.handleHistoryKey(msg)
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
+	if m.Compare != nil {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return m.handleCompareKey(msg)
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
+	if m.Events != nil {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return m.handleEventsKey(msg)
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
 	if m.Filter.Focused() {
 		updatedModel, cmd := m.handleFilterUpdate(msg)
 		if cmd != nil || updatedModel.Filter.Focused() {
@@ -131,11 +276,78 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		return m.handleResize(msg)
 
-	case etcd.ConnectionMsg, etcd.KeysMsg, etcd.ValueMsg, etcd.CountMsg:
+	case etcd.ConnectionMsg, etcd.KeysMsg, etcd.ValueMsg, etcd.CountMsg, etcd.PutMsg, etcd.DeleteMsg, etcd.PrefixCountMsg, etcd.RenameMsg,
+		etcd.ClonePlanMsg, etcd.CloneMsg, etcd.UndoMsg, etcd.ExportMsg, etcd.WatchMsg, etcd.TxnMsg, etcd.HistoryMsg, etcd.RevisionMsg, etcd.CompareMsg,
+		etcd.LargestKeysMsg, etcd.UsageMsg:
 		return m.handleEtcdMsg(msg)
 
+	case editor.ReadyMsg:
+		return m, msg.Exec()
+
+	case editor.FinishedMsg:
+		result, cmd := m.handleEditorFinished(msg)
+		return result.(Model), cmd
+
 	case CopyMsg, ClearCopyMsg:
 		return m.handleClipboardMsg(msg)
+
+	case LiveRetryMsg:
+		result, cmd := m.handleLiveRetry()
+		return result.(Model), cmd
+
+	case form.SubmitMsg:
+		result, cmd := m.handleFormSubmit(msg)
+		return result.(Model), cmd
+
+	case form.CancelMsg:
+		m.Form = nil
+		return m, nil
+
+	case confirm.ResultMsg:
+		result, cmd := m.handleConfirmResult(msg)
+		return result.(Model), cmd
+
+	case txn.AddMsg:
+		return m.handleTxnAdd(msg)
+
+	case txn.CommitMsg:
+		return m.handleTxnCommit(msg)
+
+	case txn.CloseMsg:
+		m.Txn = nil
+		return m, nil
+
+	case history.OpenMsg:
+		return m.handleHistoryOpen(msg)
+
+	case history.DiffMsg:
+		return m.handleHistoryDiff(msg)
+
+	case history.CloseMsg:
+		m.closeHistory()
+		return m, nil
+
+	case events.OpenMsg:
+		return m.handleEventOpen(msg)
+
+	case events.RestartMsg:
+		return m.handleEventRestart(msg)
+
+	case events.CloseMsg:
+		m.closeEvents()
+		return m, nil
+	}
+
+	if m.Confirm != nil {
+		c, cmd := m.Confirm.Update(msg)
+		m.Confirm = &c
+		return m, cmd
+	}
+
+	if m.Form != nil {
+		f, cmd := m.Form.Update(msg)
+		m.Form = &f
+		return m, cmd
 	}
 
 	return m, nil
@@ -160,7 +372,25 @@ func (m Model) View() string {
 	var content string
 	tableData := m.getTableViewData(contentHeight)
 
-	if m.ShowValue {
+	if m.Confirm != nil {
+		content = view.RenderModal(m.Confirm.View(), m.Width, contentHeight)
+	} else if m.Form != nil {
+		content = view.RenderModal(m.Form.View(), m.Width, contentHeight)
+	} else if m.Txn != nil {
+		m.Txn.SetSize(m.Width, contentHeight)
+		content = "\n" + m.Txn.View()
+	} else if m.Compare != nil {
+		content = m.renderCompare(contentHeight)
+	} else if m.Events != nil {
+		content = m.renderEvents(contentHeight)
+	} else if m.History != nil {
+		m.History.SetSize(view.TablePaneWidth(m.Width, m.SplitRatio), contentHeight)
+		content = m.History.View()
+		if m.ShowValue {
+			valView := view.RenderValueView(m.getValueViewData(contentHeight))
+			content = view.RenderSplitView(content, valView, m.Width, m.SplitRatio, m.DraggingSplit)
+		}
+	} else if m.ShowValue {
 		valueData := m.getValueViewData(contentHeight)
 		table := view.RenderTable(tableData)
 		valView := view.RenderValueView(valueData)
@@ -283,6 +513,12 @@ func (m Model) handleResize(msg tea.WindowSizeMsg) (Model, tea.Cmd) {
 	m.Width = msg.Width
 	m.Height = msg.Height
 	m.Header.SetWidth(m.Width)
+	if m.Form != nil {
+		m.Form.SetWidth(m.modalWidth())
+	}
+	if m.Confirm != nil {
+		m.Confirm.SetWidth(m.modalWidth())
+	}
 	m.CachedMaxVisibleRows = 0
 	m.CachedHeight = 0
 	m.fixTableViewport()
@@ -301,7 +537,60 @@ func (m Model) handleEtcdMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return result.(Model), cmd
 
 	case etcd.ValueMsg:
-		m = m.handleValueMsg(msg)
+		return m.handleValueMsg(msg)
+
+	case etcd.WatchMsg:
+		result, cmd := m.handleWatchMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.PutMsg:
+		result, cmd := m.handlePutMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.DeleteMsg:
+		result, cmd := m.handleDeleteMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.PrefixCountMsg:
+		result, cmd := m.handlePrefixCountMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.RenameMsg:
+		result, cmd := m.handleRenameMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.ClonePlanMsg:
+		result, cmd := m.handleClonePlanMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.CloneMsg:
+		result, cmd := m.handleCloneMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.UndoMsg:
+		result, cmd := m.handleUndoMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.ExportMsg:
+		result, cmd := m.handleExportMsg(msg)
+		return result.(Model), cmd
+
+	case etcd.TxnMsg:
+		return m.handleTxnMsg(msg)
+
+	case etcd.RevisionMsg:
+		return m.handleRevisionMsg(msg)
+	case etcd.CompareMsg:
+		return m.handleCompareMsg(msg)
+	case etcd.LargestKeysMsg:
+		return m.handleLargestKeysMsg(msg)
+	case etcd.UsageMsg:
+		return m.handleUsageMsg(msg)
+
+	case etcd.HistoryMsg:
+		if m.History != nil && m.History.Key() == msg.Key {
+			m.History.SetHistory(msg)
+		}
 		return m, nil
 
 	case etcd.CountMsg:
@@ -319,16 +608,9 @@ func (m Model) handleClipboardMsg(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case CopyMsg:
 		if msg.Success {
-			m.CopyMessage = msg.Message
-		} else {
-			m.CopyMessage = "Error: " + msg.Message
+			return m, (&m).flash(msg.Message)
 		}
-		m.CopyMessageTime = time.Now()
-		m.updateStatus()
-		m.updateKeyHelp()
-		return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-			return ClearCopyMsg{}
-		})
+		return m, (&m).flash("Error: " + msg.Message)
 
 	case ClearCopyMsg:
 		m.CopyMessage = ""
@@ -353,12 +635,12 @@ func (m Model) checkPagination() (Model, tea.Cmd) {
 		if m.Filter.HasFilterText() {
 			if len(m.AllKeys) <= threshold+10 {
 				m.FetchingKeys = true
-				return m, m.EtcdRepo.FetchKeys(m.LastFetchedKey, 100)
+				return m, m.EtcdRepo.FetchKeys(m.Sort, m.NextPage, 100)
 			}
 		} else {
 			if m.Cursor >= threshold && m.Cursor < len(m.FilteredKeys) && len(m.FilteredKeys) == len(m.AllKeys) {
 				m.FetchingKeys = true
-				return m, m.EtcdRepo.FetchKeys(m.LastFetchedKey, 100)
+				return m, m.EtcdRepo.FetchKeys(m.Sort, m.NextPage, 100)
 			}
 		}
 	}
@@ -366,10 +648,21 @@ func (m Model) checkPagination() (Model, tea.Cmd) {
 }
 
 func (m Model) renderError() string {
-	if m.Error != nil {
-		return style.Error.Render(fmt.Sprintf("⚠ Error: %v", m.Error))
+	if m.Error == nil {
+		return ""
+	}
+	line := "⚠ Error: " + utils.SanitizeForTUI(m.Error.Error())
+	hint, recovery := etcd.Explain(m.Error)
+	if hint != "" {
+		line += " (" + hint + ")"
+	}
+	if label := recoveryLabel(recovery); label != "" {
+		line += " • " + constants.KeyCtrlR + " " + label
 	}
-	return ""
+	if len(m.Errors) > 1 {
+		line += " • " + constants.KeyECaps + " log"
+	}
+	return style.Error.Render(line)
 }
 
 func (m Model) getTableViewData(contentHeight int) view.TableViewData {
@@ -383,15 +676,24 @@ func (m Model) getTableViewData(contentHeight int) view.TableViewData {
 		Height:       contentHeight,
 		SplitRatio:   m.SplitRatio,
 		Filter:       m.Filter,
+		Marked:       m.Marked,
+		ShowMeta:     m.ShowMeta,
 	}
 }
 
 func (m Model) getValueViewData(contentHeight int) view.ValueViewData {
-	return view.ValueViewData{
+	data := view.ValueViewData{
 		SelectedKey:    m.SelectedKey,
 		SelectedValue:  m.SelectedValue,
 		FormattedValue: m.FormattedValue,
 		IsJSON:         m.IsJSON,
+		CreateRevision: m.SelectedCreateRevision,
+		ModRevision:    m.SelectedModRevision,
+		Version:        m.SelectedVersion,
+		Lease:          m.SelectedLease,
+		LeaseTTL:       m.SelectedLeaseTTL,
+		Change:         m.ValueChange,
+		Diff:           m.DiffText,
 		ValueViewport:  m.ValueViewport,
 		Focus:          view.FocusArea(m.Focus),
 		Width:          m.Width,
@@ -399,6 +701,29 @@ func (m Model) getValueViewData(contentHeight int) view.ValueViewData {
 		SplitRatio:     m.SplitRatio,
 		DraggingSplit:  m.DraggingSplit,
 	}
+	if p := m.PastValue; p != nil {
+		data.SelectedValue = p.Value
+		data.FormattedValue = m.PastFormatted
+		data.IsJSON = m.PastIsJSON
+		data.CreateRevision = p.CreateRevision
+		data.ModRevision = p.ModRevision
+		data.Version = p.Version
+		data.Lease, data.LeaseTTL = 0, 0
+		data.Change, data.Diff = nil, ""
+		data.Notice = fmt.Sprintf("Revision %d, version %d · esc closes history", p.ModRevision, p.Version)
+	}
+	if m.PastDiff != "" {
+		data.CreateRevision, data.ModRevision, data.Version = 0, 0, 0
+		data.Lease, data.LeaseTTL = 0, 0
+		data.Change = nil
+		data.Diff = m.PastDiff
+		data.Notice = m.PastDiffTitle
+	}
+	return data
+}
+
+func (m Model) modalWidth() int {
+	return utils.Clamp(m.Width-8, constants.MinPaneWidth, 80)
 }
 
 func (m Model) calculateTableWidth() int {
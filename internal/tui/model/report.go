@@ -0,0 +1,152 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const (
+	formLargestKeys    = "largest-keys"
+	confirmLargestKeys = "largest-keys"
+	formUsage          = "usage"
+	confirmUsage       = "usage"
+)
+
+func (m Model) handleLargestKeys() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	return m.openForm(form.New(formLargestKeys, "Largest keys",
+		form.Field{Label: "Prefix", Placeholder: "blank for the whole keyspace"},
+		form.Field{Label: "Count", Value: strconv.Itoa(constants.LargestKeysDefault)},
+	))
+}
+
+func (m Model) submitLargestKeys(values []string) (tea.Model, tea.Cmd) {
+	n, err := strconv.Atoi(strings.TrimSpace(values[1]))
+	if err != nil || n < 1 || n > constants.LargestKeysMax {
+		m.Form.SetError(fmt.Sprintf("count must be between 1 and %d", constants.LargestKeysMax))
+		return m, nil
+	}
+	m.Form = nil
+	flash := (&m).flash("Scanning for the largest keys...")
+	return m, tea.Batch(flash, m.EtcdRepo.LargestKeys(values[0], n))
+}
+
+func (m Model) handleLargestKeysMsg(msg etcd.LargestKeysMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(msg.Err)
+		return m, nil
+	}
+
+	where := "the keyspace"
+	if msg.Prefix != "" {
+		where = msg.Prefix
+	}
+	title := fmt.Sprintf("Largest keys in %s", where)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d keys, %s in total\n\n", msg.Scanned, utils.FormatBytes(msg.TotalBytes))
+	keyWidth := utils.Max(10, m.modalWidth()-20)
+	for i, kv := range msg.Keys {
+		fmt.Fprintf(&b, "%3d  %9s  %s\n", i+1, utils.FormatBytes(kv.Size), utils.Truncate(kv.Key, keyWidth))
+	}
+	if len(msg.Keys) == 0 {
+		b.WriteString("No keys found.")
+	}
+
+	c := confirm.New(confirmLargestKeys, title, strings.TrimRight(b.String(), "\n")).WithChoices("Sort by size", "Close")
+	return m.openConfirm(c)
+}
+
+func (m Model) confirmLargestKeys(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Choice != "Sort by size" {
+		return m, nil
+	}
+	return m.setSort(etcd.KeySort{Field: etcd.SortBySize, Descending: true})
+}
+
+func (m Model) handleUsage() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	return m.openForm(form.New(formUsage, "Usage by prefix",
+		form.Field{Label: "Prefix", Value: m.UsagePrefix, Placeholder: "blank for the whole keyspace"},
+		form.Field{Label: "Depth", Value: strconv.Itoa(m.UsageDepth)},
+		form.Field{Label: "Delimiter", Value: m.UsageDelimiter},
+	))
+}
+
+func (m Model) submitUsage(values []string) (tea.Model, tea.Cmd) {
+	depth, err := strconv.Atoi(strings.TrimSpace(values[1]))
+	if err != nil || depth < 1 {
+		m.Form.SetError("depth must be at least 1")
+		return m, nil
+	}
+	if values[2] == "" {
+		m.Form.SetError("delimiter is required")
+		return m, nil
+	}
+	m.Form = nil
+	m.UsagePrefix, m.UsageDepth, m.UsageDelimiter = values[0], depth, values[2]
+	return m.runUsage()
+}
+
+func (m Model) runUsage() (tea.Model, tea.Cmd) {
+	flash := (&m).flash("Adding up usage...")
+	return m, tea.Batch(flash, m.EtcdRepo.Usage(m.UsagePrefix, m.UsageDelimiter, m.UsageDepth))
+}
+
+// handleUsageMsg lists the groups, largest first, as many as fit.
+func (m Model) handleUsageMsg(msg etcd.UsageMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(msg.Err)
+		return m, nil
+	}
+
+	where := "the keyspace"
+	if msg.Prefix != "" {
+		where = msg.Prefix
+	}
+	title := fmt.Sprintf("Usage of %s by %d segment(s) of %q", where, msg.Depth, msg.Delimiter)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d keys, %s in %d groups\n\n", msg.Keys, utils.FormatBytes(msg.Bytes), len(msg.Groups))
+	fmt.Fprintf(&b, "%7s  %9s  %9s  %s\n", "Keys", "Bytes", "Newest", "Group")
+	limit := utils.Max(1, m.Height-18)
+	groupWidth := utils.Max(10, m.modalWidth()-36)
+	for i, g := range msg.Groups {
+		if i == limit {
+			fmt.Fprintf(&b, "… %d more groups\n", len(msg.Groups)-limit)
+			break
+		}
+		fmt.Fprintf(&b, "%7d  %9s  %9d  %s\n", g.Keys, utils.FormatBytes(g.Bytes), g.NewestRevision, utils.Truncate(g.Prefix, groupWidth))
+	}
+
+	c := confirm.New(confirmUsage, title, strings.TrimRight(b.String(), "\n")).WithChoices("Deeper", "Shallower", "Close")
+	return m.openConfirm(c)
+}
+
+func (m Model) confirmUsage(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	switch msg.Choice {
+	case "Deeper":
+		m.UsageDepth++
+	case "Shallower":
+		if m.UsageDepth == 1 {
+			return m, nil
+		}
+		m.UsageDepth--
+	default:
+		return m, nil
+	}
+	return m.runUsage()
+}
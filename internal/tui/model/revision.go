@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+)
+
+const formRevision = "revision"
+
+func (m Model) handleGoToRevision() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	current := ""
+	if rev := m.EtcdRepo.Revision(); rev > 0 {
+		current = strconv.FormatInt(rev, 10)
+	}
+	return m.openForm(form.New(formRevision, "Go to revision",
+		form.Field{Label: "Revision", Value: current, Placeholder: "blank for the latest"},
+	))
+}
+
+func (m Model) submitRevision(values []string) (tea.Model, tea.Cmd) {
+	var rev int64
+	if s := strings.TrimSpace(values[0]); s != "" {
+		var err error
+		if rev, err = strconv.ParseInt(s, 10, 64); err != nil || rev < 0 {
+			m.Form.SetError(fmt.Sprintf("invalid revision %q", s))
+			return m, nil
+		}
+	}
+	m.Form = nil
+	return m, m.EtcdRepo.PinRevision(rev)
+}
+
+// handleRevisionMsg reloads everything once reads are pinned to another
+// revision. Nothing that was loaded at the old one is kept.
+func (m Model) handleRevisionMsg(msg etcd.RevisionMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(msg.Err)
+		return m, nil
+	}
+
+	m.clearError()
+	m.stopValueWatch()
+	if msg.Revision > 0 {
+		m.stopLive()
+	}
+	m.closeHistory()
+	m.clearValueView()
+	m.clearMarks()
+	m.Cursor = 0
+	m.TableYOffset = 0
+	m.updateBadges()
+
+	status := "Back to the latest revision"
+	if msg.Revision > 0 {
+		status = fmt.Sprintf("Viewing revision %d of %d", msg.Revision, msg.Current)
+	}
+	flash := (&m).flash(status)
+	result, cmd := m.handleRefresh()
+	return result, tea.Batch(flash, cmd)
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const formExport = "export"
+
+// handleToggleMark marks or unmarks the row under the cursor and moves down,
+// so a run of rows can be marked by holding space.
+func (m Model) handleToggleMark() (tea.Model, tea.Cmd) {
+	if m.Cursor < 0 || m.Cursor >= len(m.FilteredKeys) {
+		return m, nil
+	}
+	name := m.FilteredKeys[m.Cursor].Name
+	if m.Marked[name] {
+		delete(m.Marked, name)
+	} else {
+		m.mark(name)
+	}
+	m.Cursor = utils.Min(m.Cursor+1, len(m.FilteredKeys)-1)
+	m.fixTableViewport()
+	m.updateStatus()
+	return m, nil
+}
+
+// handleMarkRange starts a range at the cursor on the first press and marks
+// every row between the start and the cursor on the second.
+func (m Model) handleMarkRange() (tea.Model, tea.Cmd) {
+	if m.Cursor < 0 || m.Cursor >= len(m.FilteredKeys) {
+		return m, nil
+	}
+	if !m.MarkingRange {
+		m.MarkingRange = true
+		m.MarkAnchor = m.Cursor
+		return m, (&m).flash(fmt.Sprintf("Range starts at row %d, move and press V again", m.Cursor+1))
+	}
+
+	m.MarkingRange = false
+	from, to := m.MarkAnchor, m.Cursor
+	if from > to {
+		from, to = to, from
+	}
+	to = utils.Min(to, len(m.FilteredKeys)-1)
+	for i := from; i <= to; i++ {
+		m.mark(m.FilteredKeys[i].Name)
+	}
+	m.updateStatus()
+	return m, nil
+}
+
+// handleMarkAll marks every filtered row, or unmarks them when they are all
+// marked already.
+func (m Model) handleMarkAll() (tea.Model, tea.Cmd) {
+	all := len(m.FilteredKeys) > 0
+	for _, kv := range m.FilteredKeys {
+		if !m.Marked[kv.Name] {
+			all = false
+			break
+		}
+	}
+	for _, kv := range m.FilteredKeys {
+		if all {
+			delete(m.Marked, kv.Name)
+		} else {
+			m.mark(kv.Name)
+		}
+	}
+	m.updateStatus()
+	return m, nil
+}
+
+// mark selects the key stored as name. Marks are kept by name so that keys
+// which display alike stay apart.
+func (m *Model) mark(name string) {
+	if m.Marked == nil {
+		m.Marked = make(map[string]bool)
+	}
+	m.Marked[name] = true
+}
+
+func (m *Model) clearMarks() {
+	m.Marked = nil
+	m.MarkingRange = false
+}
+
+func (m Model) markedKeys() []string {
+	return slices.Sorted(maps.Keys(m.Marked))
+}
+
+// markedKeyValues returns the loaded rows for the marked keys in key order.
+func (m Model) markedKeyValues() []etcd.KeyValue {
+	rows := m.AllKeys
+	if m.FilterTriggered {
+		rows = m.PreFilterAllKeys
+	}
+	kvs := make([]etcd.KeyValue, 0, len(m.Marked))
+	for _, kv := range rows {
+		if m.Marked[kv.Name] {
+			kvs = append(kvs, kv)
+		}
+	}
+	return kvs
+}
+
+// handleCopyMarked copies the marked rows, one per line, as key=value pairs
+// or as bare keys.
+func (m Model) handleCopyMarked(keysOnly bool) (tea.Model, tea.Cmd) {
+	kvs := m.markedKeyValues()
+	if len(kvs) == 0 {
+		return m, nil
+	}
+	lines := make([]string, len(kvs))
+	for i, kv := range kvs {
+		if keysOnly {
+			lines[i] = kv.Key
+		} else {
+			lines[i] = utils.KeyValueLine(kv.Key, kv.Value)
+		}
+	}
+	return m, copyToClipboard(strings.Join(lines, "\n"))
+}
+
+func (m Model) handleDeleteMarked() (tea.Model, tea.Cmd) {
+	names := m.markedKeys()
+	m.PendingDelete = names
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = utils.SanitizeForTUI(name)
+	}
+	title := fmt.Sprintf("Delete %d selected keys?", len(keys))
+	c := confirm.New(confirmDeleteKeys, title, dryRunBody(int64(len(keys)), keys))
+	if len(keys) > constants.BulkDeleteTypedThreshold {
+		c = c.WithTypedConfirmation(strconv.Itoa(len(keys)))
+	}
+	return m.openConfirm(c)
+}
+
+// handleExport writes the marked keys, or every filtered key when nothing is
+// marked, to a JSON file.
+func (m Model) handleExport() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	count := len(m.Marked)
+	if count == 0 {
+		count = len(m.FilteredKeys)
+	}
+	if count == 0 {
+		return m, nil
+	}
+	return m.openForm(form.New(formExport, fmt.Sprintf("Export %d keys", count),
+		form.Field{Label: "File", Value: "etcd-export.json"},
+	))
+}
+
+func (m Model) submitExport(values []string) (tea.Model, tea.Cmd) {
+	path := strings.TrimSpace(values[0])
+	if path == "" {
+		m.Form.SetError("file is required")
+		return m, nil
+	}
+	m.Form = nil
+
+	keys := m.markedKeys()
+	if len(keys) == 0 {
+		keys = make([]string, len(m.FilteredKeys))
+		for i, kv := range m.FilteredKeys {
+			keys[i] = kv.Name
+		}
+	}
+	return m, m.EtcdRepo.ExportKeys(keys, path)
+}
+
+func (m Model) handleExportMsg(msg etcd.ExportMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("export to %s: %w", msg.Path, msg.Err))
+		return m, nil
+	}
+	m.setError(nil)
+	return m, (&m).flash(fmt.Sprintf("Exported %d keys to %s", msg.Count, msg.Path))
+}
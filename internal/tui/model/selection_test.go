@@ -0,0 +1,88 @@
+package model
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+)
+
+func marked(m Model) []string {
+	return slices.Sorted(maps.Keys(m.Marked))
+}
+
+func TestMarkRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		anchor, end int
+		want        []string
+	}{
+		{"downwards", 1, 3, []string{"/b", "/c", "/d"}},
+		{"upwards", 3, 1, []string{"/b", "/c", "/d"}},
+		{"a single row", 2, 2, []string{"/c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := keysModel("/a", "/b", "/c", "/d", "/e")
+			m.Cursor = tt.anchor
+			result, _ := m.handleMarkRange()
+			m = result.(Model)
+			m.Cursor = tt.end
+			result, _ = m.handleMarkRange()
+			m = result.(Model)
+
+			if got := marked(m); !slices.Equal(got, tt.want) {
+				t.Errorf("marked %q, want %q", got, tt.want)
+			}
+			if m.MarkingRange {
+				t.Error("still marking a range after the second press")
+			}
+		})
+	}
+}
+
+func TestMarksKeepKeysThatDisplayAlike(t *testing.T) {
+	m := keysModel("/x\x01", "/x\x02")
+	for i := range m.AllKeys {
+		m.AllKeys[i].Key, m.FilteredKeys[i].Key = "/x", "/x"
+	}
+
+	result, _ := m.handleToggleMark()
+	m = result.(Model)
+	if got := marked(m); !slices.Equal(got, []string{"/x\x01"}) {
+		t.Errorf("marked %q, want only the first key", got)
+	}
+}
+
+func TestDeleteClearsMarks(t *testing.T) {
+	tests := []struct {
+		name    string
+		deleted etcd.DeleteMsg
+		want    []string
+	}{
+		{"every marked key", etcd.DeleteMsg{Keys: []string{"/b", "/d"}, Deleted: 2}, nil},
+		{"some of them", etcd.DeleteMsg{Keys: []string{"/b"}, Deleted: 1}, []string{"/d"}},
+		{"one already gone", etcd.DeleteMsg{Keys: []string{"/b"}, Missing: []string{"/d"}, Deleted: 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := keysModel("/a", "/b", "/c", "/d")
+			m.mark("/b")
+			m.mark("/d")
+			result, _ := m.handleDeleteMarked()
+			m = result.(Model)
+			if !slices.Equal(m.PendingDelete, []string{"/b", "/d"}) {
+				t.Fatalf("PendingDelete = %q, want the marked keys", m.PendingDelete)
+			}
+
+			result, _ = m.handleDeleteMsg(tt.deleted)
+			m = result.(Model)
+			if got := marked(m); !slices.Equal(got, tt.want) {
+				t.Errorf("marked %q after the delete, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+)
+
+// handleSort moves on to the next sort field, or flips the direction when
+// reverse is set.
+func (m Model) handleSort(reverse bool) (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	if reverse {
+		return m.setSort(m.Sort.Reverse())
+	}
+	return m.setSort(m.Sort.Next())
+}
+
+// setSort reloads the key list in the order s. While a filter is applied
+// every key is loaded already, so they are sorted in place.
+func (m Model) setSort(s etcd.KeySort) (tea.Model, tea.Cmd) {
+	m.Sort = s
+	m.Cursor = 0
+	m.TableYOffset = 0
+
+	if m.FilterTriggered {
+		etcd.SortKeys(m.AllKeys, m.Sort)
+		m.FilteredKeys = m.filterKeys()
+		m.fixTableViewport()
+		return m, (&m).flash("Sorted by " + m.Sort.String())
+	}
+
+	flash := (&m).flash("Sorted by " + m.Sort.String())
+	result, cmd := m.handleRefresh()
+	return result, tea.Batch(flash, cmd)
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/txn"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const (
+	formTxnCompare = "txn-compare"
+	formTxnOp      = "txn-op"
+	confirmTxn     = "txn"
+)
+
+// handleOpenTxn opens the transaction builder with the marked keys, or the
+// key under the cursor, picked.
+func (m Model) handleOpenTxn() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	keys := m.markedKeys()
+	if len(keys) == 0 && m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
+		keys = []string{m.FilteredKeys[m.Cursor].Key}
+	}
+	t := txn.New(keys)
+	m.Txn = &t
+	return m, nil
+}
+
+func (m Model) handleTxnAdd(msg txn.AddMsg) (tea.Model, tea.Cmd) {
+	if m.Txn == nil {
+		return m, nil
+	}
+	key := m.Txn.DefaultKey()
+	if msg.Section == txn.Compares {
+		return m.openForm(form.New(formTxnCompare, "Add compare",
+			form.Field{Label: "Key", Value: key},
+			form.Field{Label: "Target", Value: "mod", Placeholder: "value, version, create, mod or lease"},
+			form.Field{Label: "Operator", Value: "=", Placeholder: "=, !=, < or >"},
+			form.Field{Label: "Against", Placeholder: "a revision, version, hex lease id or value"},
+		))
+	}
+	return m.openForm(form.New(formTxnOp, "Add to "+msg.Section.String(),
+		form.Field{Label: "Operation", Value: "put", Placeholder: "get, put or delete"},
+		form.Field{Label: "Key", Value: key},
+		form.Field{Label: "Value (put only)", Multiline: true},
+	))
+}
+
+func (m Model) submitTxnCompare(values []string) (tea.Model, tea.Cmd) {
+	c, err := etcd.ParseCompare(strings.TrimSpace(values[0]), values[1], values[2], values[3])
+	if err != nil {
+		m.Form.SetError(err.Error())
+		return m, nil
+	}
+	m.Form = nil
+	if m.Txn != nil {
+		m.Txn.AddCompare(c)
+	}
+	return m, nil
+}
+
+func (m Model) submitTxnOp(values []string) (tea.Model, tea.Cmd) {
+	op, err := etcd.ParseTxnOp(values[0], strings.TrimSpace(values[1]), values[2])
+	if err != nil {
+		m.Form.SetError(err.Error())
+		return m, nil
+	}
+	m.Form = nil
+	if m.Txn != nil {
+		m.Txn.AddOp(op)
+	}
+	return m, nil
+}
+
+func (m Model) handleTxnCommit(msg txn.CommitMsg) (tea.Model, tea.Cmd) {
+	return m.openConfirm(confirm.New(confirmTxn, "Commit transaction?", txnBody(msg.Spec)))
+}
+
+// txnBody lays the transaction out the way etcdctl txn reads it.
+func txnBody(spec etcd.TxnSpec) string {
+	var b strings.Builder
+	section := func(title string, lines []string) {
+		b.WriteString(title + "\n")
+		if len(lines) == 0 {
+			b.WriteString("  (none)\n")
+		}
+		for _, line := range lines {
+			b.WriteString("  " + utils.NormalizeForDisplay(line, 120) + "\n")
+		}
+	}
+
+	compares := make([]string, len(spec.Compares))
+	for i, c := range spec.Compares {
+		compares[i] = c.String()
+	}
+	section("compares:", compares)
+	b.WriteString("\n")
+	section("success requests:", opStrings(spec.Success))
+	b.WriteString("\n")
+	section("failure requests:", opStrings(spec.Failure))
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func opStrings(ops []etcd.TxnOp) []string {
+	lines := make([]string, len(ops))
+	for i, op := range ops {
+		lines[i] = op.String()
+	}
+	return lines
+}
+
+func (m Model) handleTxnMsg(msg etcd.TxnMsg) (tea.Model, tea.Cmd) {
+	if m.Txn != nil {
+		m.Txn.SetResult(msg)
+	}
+	if msg.Err != nil {
+		return m, nil
+	}
+
+	m.pushUndo(msg.Changes)
+	if len(msg.Changes) > 0 {
+		m.noteOwnWrites(msg.Revision)
+	}
+	if m.TotalKeys >= 0 {
+		for _, c := range msg.Changes {
+			if c.Prev == nil {
+				m.TotalKeys++
+			}
+		}
+	}
+
+	var removed []string
+	for _, res := range msg.Results {
+		switch res.Op.Type {
+		case etcd.TxnPut:
+			m.upsertKeys(res.KVs...)
+		case etcd.TxnDelete:
+			if res.Deleted > 0 {
+				removed = append(removed, res.Op.Key)
+				if m.TotalKeys >= 0 {
+					m.TotalKeys = utils.Max(0, m.TotalKeys-int(res.Deleted))
+				}
+			}
+		}
+	}
+	m.removeKeys(removed)
+	m.updateStatus()
+
+	branch := "failure"
+	if msg.Succeeded {
+		branch = "success"
+	}
+	return m, (&m).flash(fmt.Sprintf("Transaction committed at revision %d (%s branch)", msg.Revision, branch))
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// pushUndo records the changes made by one write as a single undo step.
+func (m *Model) pushUndo(changes []etcd.Change) {
+	if len(changes) == 0 {
+		return
+	}
+	m.UndoStack = append(m.UndoStack, changes)
+	if len(m.UndoStack) > constants.UndoStackSize {
+		m.UndoStack = m.UndoStack[len(m.UndoStack)-constants.UndoStackSize:]
+	}
+}
+
+func (m Model) handleUndo() (tea.Model, tea.Cmd) {
+	if !m.Connected || m.UndoInFlight {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+	if len(m.UndoStack) == 0 {
+		return m, (&m).flash("Nothing to undo")
+	}
+
+	last := m.UndoStack[len(m.UndoStack)-1]
+	m.UndoStack = m.UndoStack[:len(m.UndoStack)-1]
+	m.UndoInFlight = true
+	return m, m.EtcdRepo.Undo(last)
+}
+
+func (m Model) handleUndoMsg(msg etcd.UndoMsg) (tea.Model, tea.Cmd) {
+	m.UndoInFlight = false
+	m.noteOwnWrites(msg.Written...)
+	m.upsertKeys(msg.Restored...)
+	m.removeKeys(msg.Removed)
+	if m.TotalKeys >= 0 {
+		for _, c := range msg.Reverted {
+			if c.Revision == 0 {
+				m.TotalKeys++ // a deleted key came back
+			}
+			if c.Prev == nil {
+				m.TotalKeys-- // a created key went away
+			}
+		}
+		m.TotalKeys = utils.Max(0, m.TotalKeys)
+	}
+	m.updateStatus()
+	for _, changes := range m.UndoStack {
+		msg.Rebase(changes)
+	}
+
+	var cmd tea.Cmd
+	if m.ShowValue && undoTouches(msg, m.SelectedName) {
+		m.ValueLoading = true
+		cmd = m.EtcdRepo.FetchValue(m.SelectedName)
+	}
+
+	if msg.Err != nil {
+		// A conflict or an expired lease will not go away on retry, so only
+		// keep the step around for errors that might.
+		if len(msg.Pending) > 0 && !errors.Is(msg.Err, etcd.ErrRevisionConflict) && !errors.Is(msg.Err, etcd.ErrLeaseExpired) {
+			m.pushUndo(msg.Pending)
+		}
+		m.setError(fmt.Errorf("undo: %w", msg.Err))
+		return m, cmd
+	}
+
+	m.setError(nil)
+	text := fmt.Sprintf("Undid changes to %d keys", len(msg.Reverted))
+	if len(msg.Reverted) == 1 {
+		text = "Undid change to " + utils.SanitizeForTUI(msg.Reverted[0].Key)
+	}
+	return m, tea.Batch(cmd, (&m).flash(text))
+}
+
+func undoTouches(msg etcd.UndoMsg, name string) bool {
+	for _, kv := range msg.Restored {
+		if kv.Name == name {
+			return true
+		}
+	}
+	for _, k := range msg.Removed {
+		if k == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+// watchSelected restarts the watch on the open key from the revision that is
+// displayed, dropping any pending change notice.
+func (m *Model) watchSelected() tea.Cmd {
+	m.stopValueWatch()
+	m.ValueWatch = m.EtcdRepo.WatchKey(m.SelectedName, m.SelectedModRevision)
+	return m.ValueWatch.Next()
+}
+
+func (m *Model) stopValueWatch() {
+	m.ValueWatch.Stop()
+	m.ValueWatch = nil
+	m.ValueChange = nil
+	m.ShowDiff = false
+	m.DiffText = ""
+}
+
+func (m Model) handleWatchMsg(msg etcd.WatchMsg) (tea.Model, tea.Cmd) {
+	if msg.Watcher != nil && msg.Watcher == m.LiveWatch {
+		return m.handleLiveWatchMsg(msg)
+	}
+	if msg.Watcher != nil && msg.Watcher == m.EventWatch {
+		return m.handleEventWatchMsg(msg)
+	}
+	if msg.Watcher == nil || msg.Watcher != m.ValueWatch {
+		return m, nil
+	}
+	if msg.Closed {
+		m.ValueWatch = nil
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.ValueWatch.Stop()
+		m.ValueWatch = nil
+		m.setError(fmt.Errorf("watch %s: %w", m.SelectedKey, msg.Err))
+		return m, nil
+	}
+
+	for _, ev := range msg.Events {
+		if ev.KV.Name == m.SelectedName && ev.Revision > m.SelectedModRevision {
+			m.ValueChange = &ev
+		}
+	}
+	if m.ShowDiff {
+		m.DiffText = m.valueDiff()
+	}
+	return m, m.ValueWatch.Next()
+}
+
+// handleReloadValue fetches the open key again, which also restarts its watch.
+func (m Model) handleReloadValue() (tea.Model, tea.Cmd) {
+	if m.SelectedName == "" || m.ValueLoading {
+		return m, nil
+	}
+	m.ValueLoading = true
+	return m, m.EtcdRepo.FetchValue(m.SelectedName)
+}
+
+func (m Model) handleToggleDiff() (tea.Model, tea.Cmd) {
+	if !m.ShowValue || m.ValueChange == nil {
+		return m, nil
+	}
+	m.ShowDiff = !m.ShowDiff
+	m.DiffText = ""
+	if m.ShowDiff {
+		m.DiffText = m.valueDiff()
+	}
+	m.ValueViewport = 0
+	return m, nil
+}
+
+// valueDiff diffs the displayed value against the latest one seen by the
+// watch, comparing formatted JSON when both sides are JSON.
+func (m Model) valueDiff() string {
+	if m.ValueChange == nil {
+		return ""
+	}
+	before := utils.SanitizeForTUI(m.SelectedRaw)
+	after := ""
+	if !m.ValueChange.Deleted {
+		after = utils.SanitizeForTUI(m.ValueChange.Value)
+	}
+	if formatted, ok := utils.FormatJSON(before); ok {
+		if formattedAfter, ok := utils.FormatJSON(after); ok {
+			before, after = formatted, formattedAfter
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("--- displayed (revision %d)", m.SelectedModRevision),
+		fmt.Sprintf("+++ revision %d", m.ValueChange.Revision),
+	}
+	lines = append(lines, utils.UnifiedDiff(before, after, 3)...)
+	return strings.Join(lines, "\n")
+}
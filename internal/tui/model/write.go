@@ -0,0 +1,382 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/confirm"
+	"github.com/olamilekan000/etcd-tui/internal/tui/components/form"
+	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
+	"github.com/olamilekan000/etcd-tui/internal/tui/editor"
+	"github.com/olamilekan000/etcd-tui/internal/utils"
+)
+
+const (
+	formNewKey = "new-key"
+	formRename = "rename"
+	formLease  = "lease"
+
+	confirmOverwrite   = "overwrite"
+	confirmInvalidJSON = "invalid-json"
+	choiceReopen       = "Reopen"
+	choiceSaveRaw      = "Save raw"
+	choiceDiscard      = "Discard"
+)
+
+// blockWrite reports whether the repository refuses writes, flashing the
+// reason so the user is told before filling in a form.
+func (m *Model) blockWrite() (bool, tea.Cmd) {
+	if rev := m.EtcdRepo.Revision(); rev > 0 {
+		return true, m.flash(fmt.Sprintf("Viewing revision %d: writes are disabled", rev))
+	}
+	if !m.EtcdRepo.ReadOnly() {
+		return false, nil
+	}
+	return true, m.flash("Read-only mode: writes are disabled")
+}
+
+func (m Model) handleEdit() (tea.Model, tea.Cmd) {
+	if !m.ShowValue || m.SelectedName == "" || m.ValueLoading {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+	m.EditModRevision = m.SelectedModRevision
+	return m, editor.Open(m.SelectedName, m.SelectedRaw)
+}
+
+func (m Model) handleEditorFinished(msg editor.FinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("editor: %w", msg.Err))
+		return m, nil
+	}
+	if !msg.Changed() {
+		return m, (&m).flash("No changes to " + msg.Key)
+	}
+
+	// A value that was JSON is most likely config; don't let a typo in the
+	// editor write it back broken.
+	if _, wasJSON := utils.FormatJSON(msg.Original); wasJSON {
+		if err := utils.ValidateJSON(msg.Value); err != nil {
+			m.PendingEdit = msg
+			c := confirm.New(confirmInvalidJSON, "Invalid JSON", invalidJSONBody(msg.Key, msg.Value, err)).
+				WithChoices(choiceReopen, choiceSaveRaw, choiceDiscard)
+			return m.openConfirm(c)
+		}
+	}
+	return m, m.EtcdRepo.UpdateValue(msg.Key, msg.Value, m.EditModRevision, etcd.LeaseOption{})
+}
+
+func (m Model) confirmInvalidJSON(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	edit := m.PendingEdit
+	m.PendingEdit = editor.FinishedMsg{}
+
+	switch msg.Choice {
+	case choiceReopen:
+		return m, editor.Reopen(edit.Key, edit.Original, edit.Value)
+	case choiceSaveRaw:
+		return m, m.EtcdRepo.UpdateValue(edit.Key, edit.Value, m.EditModRevision, etcd.LeaseOption{})
+	}
+	return m, (&m).flash("Discarded edit to " + edit.Key)
+}
+
+// PendingCreate is a new key whose name turned out to be taken, kept while
+// the user decides whether to overwrite it.
+type PendingCreate struct {
+	Key         string
+	Value       string
+	Lease       etcd.LeaseOption
+	ModRevision int64
+}
+
+func (m Model) askOverwrite(existing etcd.KeyValue) (tea.Model, tea.Cmd) {
+	m.PendingCreate.ModRevision = existing.ModRevision
+	body := fmt.Sprintf("%s already exists (revision %d, version %d):\n\n%s\n\nOverwrite it? Its lease is kept unless a new one was given.",
+		existing.Key, existing.ModRevision, existing.Version, existing.ValuePreview)
+	return m.openConfirm(confirm.New(confirmOverwrite, "Key exists", body))
+}
+
+// confirmOverwrite writes the new key's value over the existing key, unless
+// it changed again while the user was deciding.
+func (m Model) confirmOverwrite(msg confirm.ResultMsg) (tea.Model, tea.Cmd) {
+	create := m.PendingCreate
+	m.PendingCreate = PendingCreate{}
+	if !msg.Confirmed {
+		return m, (&m).flash(create.Key + " was left as it was")
+	}
+	return m, m.EtcdRepo.UpdateValue(create.Key, create.Value, create.ModRevision, create.Lease)
+}
+
+// invalidJSONBody explains the parse error and points at it in the value.
+func invalidJSONBody(key, value string, err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s held JSON, but the edited value does not parse:\n\n%v", key, err)
+
+	var syntaxErr *utils.JSONSyntaxError
+	if errors.As(err, &syntaxErr) {
+		lines := strings.Split(value, "\n")
+		if syntaxErr.Line <= len(lines) {
+			line := strings.ReplaceAll(strings.TrimRight(lines[syntaxErr.Line-1], "\r"), "\t", " ")
+			gutter := fmt.Sprintf("%4d | ", syntaxErr.Line)
+			fmt.Fprintf(&b, "\n\n%s%s\n%s^", gutter, line, strings.Repeat(" ", len(gutter)+syntaxErr.Column-1))
+		}
+	}
+	return b.String()
+}
+
+func (m Model) handlePutMsg(msg etcd.PutMsg) (tea.Model, tea.Cmd) {
+	if msg.Key == m.PendingCreate.Key {
+		if errors.Is(msg.Err, etcd.ErrKeyExists) {
+			return m.askOverwrite(msg.KV)
+		}
+		m.PendingCreate = PendingCreate{}
+	}
+	if msg.Err != nil {
+		if errors.Is(msg.Err, etcd.ErrRevisionConflict) {
+			m.setError(fmt.Errorf("not saved %s: %w (press enter to reload)", msg.Key, msg.Err))
+		} else {
+			m.setError(fmt.Errorf("write %s: %w", msg.Key, msg.Err))
+		}
+		return m, nil
+	}
+
+	m.setError(nil)
+	m.pushUndo(msg.Changes)
+	m.noteOwnWrites(msg.Revision)
+	m.upsertKeys(msg.KV)
+	if msg.Created {
+		if m.TotalKeys >= 0 {
+			m.TotalKeys++
+		}
+		m.moveCursorToKey(msg.Key)
+	}
+	cmd := (&m).flash(fmt.Sprintf("Saved %s (revision %d)", msg.Key, msg.Revision))
+
+	if m.ShowValue && m.SelectedName == msg.Key {
+		// Our own write is not a change made underneath the user.
+		m.SelectedModRevision = msg.Revision
+		m.ValueLoading = true
+		return m, tea.Batch(cmd, m.EtcdRepo.FetchValue(msg.Key))
+	}
+	return m, cmd
+}
+
+func (m Model) handleNewKey() (tea.Model, tea.Cmd) {
+	if !m.Connected {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+
+	prefix := ""
+	if m.Cursor >= 0 && m.Cursor < len(m.FilteredKeys) {
+		prefix = utils.KeyPrefix(m.FilteredKeys[m.Cursor].Key)
+	}
+
+	return m.openForm(form.New(formNewKey, "New key",
+		form.Field{Label: "Key", Value: prefix},
+		form.Field{Label: "Value", Multiline: true},
+		form.Field{Label: "Lease (optional)", Placeholder: "ttl=30s or lease=<hex id>"},
+	))
+}
+
+// handleLease changes the lease of the open key, keeping its value.
+func (m Model) handleLease() (tea.Model, tea.Cmd) {
+	if !m.ShowValue || m.SelectedName == "" || m.ValueLoading {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+
+	current := ""
+	if m.SelectedLease != 0 {
+		current = "lease=" + etcd.FormatLeaseID(m.SelectedLease)
+	}
+	m.EditModRevision = m.SelectedModRevision
+	return m.openForm(form.New(formLease, "Lease for "+m.SelectedKey,
+		form.Field{Label: "Lease", Value: current, Placeholder: "ttl=30s, lease=<hex id> or none"},
+	))
+}
+
+func (m Model) handleRename() (tea.Model, tea.Cmd) {
+	if !m.Connected || m.Cursor < 0 || m.Cursor >= len(m.FilteredKeys) {
+		return m, nil
+	}
+	if blocked, cmd := (&m).blockWrite(); blocked {
+		return m, cmd
+	}
+
+	m.PendingRename = m.FilteredKeys[m.Cursor]
+	key := m.PendingRename.Key
+	return m.openForm(form.New(formRename, "Move / rename",
+		form.Field{Label: "From (end with / to move a whole prefix)", Value: key},
+		form.Field{Label: "To", Value: key},
+	))
+}
+
+func (m Model) handleRenameMsg(msg etcd.RenameMsg) (tea.Model, tea.Cmd) {
+	m.pushUndo(msg.Changes)
+	m.noteOwnWrites(msg.Revisions...)
+	m.removeKeys(msg.Removed)
+	m.upsertKeys(msg.Moved...)
+
+	if msg.Err != nil {
+		m.setError(fmt.Errorf("move %s to %s: %w", msg.From, msg.To, msg.Err))
+		return m, nil
+	}
+
+	m.setError(nil)
+	if len(msg.Moved) == 1 {
+		m.moveCursorToKey(msg.Moved[0].Name)
+		return m, (&m).flash(fmt.Sprintf("Moved %s to %s", msg.From, msg.To))
+	}
+	return m, (&m).flash(fmt.Sprintf("Moved %d keys from %s to %s", len(msg.Moved), msg.From, msg.To))
+}
+
+func (m Model) openForm(f form.Model) (tea.Model, tea.Cmd) {
+	f.SetWidth(m.modalWidth())
+	m.Form = &f
+	return m, f.Init()
+}
+
+func (m Model) handleFormSubmit(msg form.SubmitMsg) (tea.Model, tea.Cmd) {
+	switch msg.ID {
+	case formNewKey:
+		key := strings.TrimSpace(msg.Values[0])
+		if key == "" {
+			m.Form.SetError("key is required")
+			return m, nil
+		}
+		lease, err := etcd.ParseLeaseOption(msg.Values[2])
+		if err != nil {
+			m.Form.SetError(err.Error())
+			return m, nil
+		}
+		m.Form = nil
+		m.PendingCreate = PendingCreate{Key: key, Value: msg.Values[1], Lease: lease}
+		return m, m.EtcdRepo.PutKey(key, msg.Values[1], lease)
+
+	case formRevision:
+		return m.submitRevision(msg.Values)
+
+	case formLargestKeys:
+		return m.submitLargestKeys(msg.Values)
+
+	case formUsage:
+		return m.submitUsage(msg.Values)
+
+	case formWatchEvents:
+		return m.submitWatchEvents(msg.Values)
+
+	case formLease:
+		lease, err := etcd.ParseLeaseOption(msg.Values[0])
+		if err != nil {
+			m.Form.SetError(err.Error())
+			return m, nil
+		}
+		m.Form = nil
+		if lease.IsZero() || m.SelectedName == "" {
+			return m, nil
+		}
+		return m, m.EtcdRepo.UpdateValue(m.SelectedName, m.SelectedRaw, m.EditModRevision, lease)
+
+	case formDeletePrefix:
+		prefix := msg.Values[0]
+		if strings.TrimSpace(prefix) == "" {
+			m.Form.SetError("prefix is required")
+			return m, nil
+		}
+		m.Form = nil
+		return m, m.EtcdRepo.CountPrefix(prefix, constants.DeleteSampleSize)
+
+	case formClone:
+		return m.submitClone(msg.Values)
+
+	case formExport:
+		return m.submitExport(msg.Values)
+
+	case formTxnCompare:
+		return m.submitTxnCompare(msg.Values)
+
+	case formTxnOp:
+		return m.submitTxnOp(msg.Values)
+
+	case formRename:
+		from, to := msg.Values[0], msg.Values[1]
+		if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+			m.Form.SetError("both keys are required")
+			return m, nil
+		}
+		if from == to {
+			m.Form.SetError("source and destination are the same")
+			return m, nil
+		}
+		m.Form = nil
+		if strings.HasSuffix(from, "/") {
+			return m, m.EtcdRepo.RenamePrefix(from, to)
+		}
+		// The form shows the key made safe to display; left as it was, it
+		// stands for the key as stored.
+		if from == m.PendingRename.Key {
+			from = m.PendingRename.Name
+		}
+		return m, m.EtcdRepo.RenameKey(from, to)
+	}
+
+	m.Form = nil
+	return m, nil
+}
+
+// upsertKeys replaces the rows for each key, or inserts them in key order
+// when they are not loaded yet, and re-applies the current filter.
+func (m *Model) upsertKeys(kvs ...etcd.KeyValue) {
+	if len(kvs) == 0 {
+		return
+	}
+	for _, kv := range kvs {
+		m.AllKeys = upsertSorted(m.AllKeys, kv, m.Sort)
+		if m.FilterTriggered {
+			m.PreFilterAllKeys = upsertSorted(m.PreFilterAllKeys, kv, m.Sort)
+		}
+	}
+	m.refreshFilteredKeys()
+}
+
+func (m *Model) refreshFilteredKeys() {
+	if m.Filter.HasFilterText() {
+		m.FilteredKeys = m.filterKeys()
+	} else {
+		m.FilteredKeys = make([]etcd.KeyValue, len(m.AllKeys))
+		copy(m.FilteredKeys, m.AllKeys)
+	}
+	m.fixTableViewport()
+	m.updateStatus()
+}
+
+func (m *Model) moveCursorToKey(name string) {
+	for i, kv := range m.FilteredKeys {
+		if kv.Name == name {
+			m.Cursor = i
+			m.fixTableViewport()
+			return
+		}
+	}
+}
+
+// upsertSorted replaces kv's row, or inserts it, where order puts it. A
+// replaced row moves when the write changed its place, e.g. its mod revision.
+func upsertSorted(keys []etcd.KeyValue, kv etcd.KeyValue, order etcd.KeySort) []etcd.KeyValue {
+	if i := slices.IndexFunc(keys, func(k etcd.KeyValue) bool { return k.Name == kv.Name }); i >= 0 {
+		keys = slices.Delete(keys, i, i+1)
+	}
+	idx, _ := slices.BinarySearchFunc(keys, kv, order.Compare)
+	return slices.Insert(keys, idx, kv)
+}
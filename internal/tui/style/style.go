@@ -33,11 +33,23 @@ var (
 	TableHeader   = Regular.Foreground(amberGold).Bold(true).Underline(true)
 	SelectedRow   = Regular.Foreground(lipgloss.Color("#FFFFFF")).Background(blue).Bold(true)
 	Row           = Regular.Foreground(lipgloss.Color("#FAFAFA"))
+	MarkedRow     = Regular.Foreground(black).Background(amberGold)
 	KeyColumn     = Regular.Foreground(yellow)
 	ValueColumn   = Regular.Foreground(dullGreen)
 	RowNumber     = Regular.Foreground(warmGrey)
 	Separator     = Regular.Foreground(warmGrey)
 	SeparatorDrag = Regular.Foreground(lipgloss.Color("#00D9FF")).Background(lipgloss.Color("#333333"))
 	Badge         = Regular.Foreground(lipgloss.Color("#04B575")).Bold(true)
+	Banner        = Regular.Foreground(black).Background(yellow).Bold(true)
+	DiffAdd       = Regular.Foreground(lipgloss.Color("#04B575"))
+	DiffDel       = Regular.Foreground(red)
+	DiffHunk      = Regular.Foreground(blue)
+	DiffChange    = Regular.Foreground(yellow)
+	HeaderBadge   = Regular.Foreground(black).Background(red).Bold(true).Padding(0, 1)
 	Focused       = Regular.Foreground(lipgloss.Color("#00D9FF")).Bold(true)
+	Modal         = Regular.Padding(1, 2).Border(lipgloss.RoundedBorder(), true).BorderForeground(amberGold)
+	FormTitle     = Regular.Foreground(amberGold).Bold(true)
+	FormLabel     = Regular.Foreground(grey)
+	Button        = Regular.Padding(0, 2).Foreground(grey).Border(lipgloss.NormalBorder(), true).BorderForeground(warmGrey)
+	ButtonActive  = Button.Foreground(black).Background(blue).BorderForeground(blue).Bold(true)
 )
@@ -0,0 +1,8 @@
+package view
+
+import "github.com/charmbracelet/lipgloss"
+
+// RenderModal centers a dialog in the content area.
+func RenderModal(dialog string, width, height int) string {
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
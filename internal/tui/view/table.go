@@ -19,8 +19,12 @@ const (
 	FocusTable FocusArea = "table"
 	FocusValue FocusArea = "value"
 	ColumnGap            = "      "
+	metaGap              = "  "
 )
 
+// metaWidth is the width of the optional metadata columns; see metaCells.
+var metaWidth = lipgloss.Width(metaHeader())
+
 type TableViewData struct {
 	FilteredKeys []etcd.KeyValue
 	Cursor       int
@@ -31,6 +35,8 @@ type TableViewData struct {
 	Height       int
 	SplitRatio   float64
 	Filter       filter.Model
+	Marked       map[string]bool
+	ShowMeta     bool
 }
 
 type ValueViewData struct {
@@ -38,12 +44,21 @@ type ValueViewData struct {
 	SelectedValue  string
 	FormattedValue string
 	IsJSON         bool
+	CreateRevision int64
+	ModRevision    int64
+	Version        int64
+	Lease          int64
+	LeaseTTL       int64
+	Change         *etcd.WatchEvent
+	Notice         string
+	Diff           string
 	ValueViewport  int
 	Focus          FocusArea
 	Width          int
 	Height         int
 	SplitRatio     float64
 	DraggingSplit  bool
+	PaneWidth      int // overrides the right pane's width, e.g. for a left pane
 }
 
 func RenderTable(data TableViewData) string {
@@ -72,6 +87,11 @@ func RenderTable(data TableViewData) string {
 	return finalizeOutput(b.String(), width)
 }
 
+// TablePaneWidth is the width of the left pane while the value pane is open.
+func TablePaneWidth(totalWidth int, ratio float64) int {
+	return calculatePaneWidth(totalWidth, ratio, true)
+}
+
 func calculatePaneWidth(totalWidth int, ratio float64, showValue bool) int {
 	if !showValue {
 		return totalWidth
@@ -99,13 +119,7 @@ func renderTableHeader(data TableViewData, width int) string {
 		return headerRendered + "\n" + strings.Repeat("─", width-constants.HeaderPadding) + "\n"
 	}
 
-	availableWidth := width - 3
-	var kWidth, vWidth int
-	if data.Filter.HasFilterText() {
-		kWidth, vWidth = calculateFilteredColumnWidths(availableWidth)
-	} else {
-		kWidth, vWidth = calculateNormalColumnWidths(availableWidth)
-	}
+	kWidth, vWidth := fullRowWidths(data, width-3)
 
 	keyH := style.TableHeader.Render("Key")
 	valH := style.TableHeader.Render("Value")
@@ -125,7 +139,11 @@ func renderTableHeader(data TableViewData, width int) string {
 	keyH = padToWidth(keyH, kWidth)
 	valH = padToWidth(valH, vWidth)
 
-	return keyH + ColumnGap + valH + "\n" + strings.Repeat("─", width-constants.HeaderPadding) + "\n"
+	header := keyH + ColumnGap + valH
+	if data.ShowMeta {
+		header += metaGap + style.TableHeader.Render(metaHeader())
+	}
+	return header + "\n" + strings.Repeat("─", width-constants.HeaderPadding) + "\n"
 }
 
 func calculateVisibleIndices(data TableViewData, maxRows int) (startIdx, endIdx int) {
@@ -186,16 +204,17 @@ func renderRow(idx int, data TableViewData, width int) string {
 
 	kv := data.FilteredKeys[idx]
 	selected := idx == data.Cursor
-	cursor := getCursorIndicator(selected)
+	marked := data.Marked[kv.Name]
+	cursor := getCursorIndicator(selected, marked)
 
 	if data.ShowValue {
-		return renderKeyOnlyRow(idx, kv, cursor, width, selected)
+		return renderKeyOnlyRow(idx, kv, cursor, width, selected, marked)
 	}
 
-	return renderFullRow(idx, kv, cursor, data, width, selected)
+	return renderFullRow(idx, kv, cursor, data, width, selected, marked)
 }
 
-func renderKeyOnlyRow(idx int, kv etcd.KeyValue, cursor string, width int, selected bool) string {
+func renderKeyOnlyRow(idx int, kv etcd.KeyValue, cursor string, width int, selected, marked bool) string {
 	numberWidth := 8
 	keyDisplay := truncateString(kv.Key, width-6-numberWidth)
 	rowNumber := style.RowNumber.Render(fmt.Sprintf("%4d ", idx+1))
@@ -205,7 +224,7 @@ func renderKeyOnlyRow(idx int, kv etcd.KeyValue, cursor string, width int, selec
 		line = utils.Truncate(line, width)
 	}
 
-	rowStyle := getRowStyle(selected).
+	rowStyle := getRowStyle(selected, marked).
 		MaxWidth(width).
 		MaxHeight(1).
 		Inline(true)
@@ -213,15 +232,8 @@ func renderKeyOnlyRow(idx int, kv etcd.KeyValue, cursor string, width int, selec
 	return rowStyle.Render(line) + "\n"
 }
 
-func renderFullRow(idx int, kv etcd.KeyValue, cursor string, data TableViewData, width int, selected bool) string {
-	availableWidth := width - 3
-	var kWidth, vWidth int
-
-	if data.Filter.HasFilterText() {
-		kWidth, vWidth = calculateFilteredColumnWidths(availableWidth)
-	} else {
-		kWidth, vWidth = calculateNormalColumnWidths(availableWidth)
-	}
+func renderFullRow(idx int, kv etcd.KeyValue, cursor string, data TableViewData, width int, selected, marked bool) string {
+	kWidth, vWidth := fullRowWidths(data, width-3)
 
 	keyContent := kv.Key
 	valContent := kv.ValuePreview
@@ -231,8 +243,18 @@ func renderFullRow(idx int, kv etcd.KeyValue, cursor string, data TableViewData,
 		keyContent = rowNumber + keyContent
 	}
 
-	if selected {
-		return renderSelectedRow(cursor, keyContent, valContent, kWidth, vWidth, width)
+	if selected || marked {
+		if data.ShowMeta {
+			valWidth := utils.Max(0, vWidth-2)
+			valContent = padToWidth(truncateString(valContent, valWidth), valWidth) + metaGap + metaCells(kv)
+			vWidth += len(metaGap) + metaWidth
+		}
+		return renderHighlightedRow(cursor, keyContent, valContent, kWidth, vWidth, width, getRowStyle(selected, marked))
+	}
+
+	var metaCell string
+	if data.ShowMeta {
+		metaCell = metaGap + style.RowNumber.Render(metaCells(kv))
 	}
 
 	keyDisplay := truncateString(keyContent, kWidth-2)
@@ -244,7 +266,7 @@ func renderFullRow(idx int, kv etcd.KeyValue, cursor string, data TableViewData,
 	keyCell = padToWidth(keyCell, kWidth)
 	valCell = padToWidth(valCell, vWidth)
 
-	rowContent := cursor + keyCell + ColumnGap + valCell
+	rowContent := cursor + keyCell + ColumnGap + valCell + metaCell
 
 	if lipgloss.Width(rowContent) > width {
 		if data.Filter.HasFilterText() {
@@ -263,7 +285,7 @@ func renderFullRow(idx int, kv etcd.KeyValue, cursor string, data TableViewData,
 		valCell = style.ValueColumn.Render(valDisplay)
 		keyCell = padToWidth(keyCell, kWidth)
 		valCell = padToWidth(valCell, vWidth)
-		rowContent = cursor + keyCell + ColumnGap + valCell
+		rowContent = cursor + keyCell + ColumnGap + valCell + metaCell
 		if lipgloss.Width(rowContent) > width {
 			rowContent = utils.Truncate(rowContent, width)
 		}
@@ -277,6 +299,30 @@ func renderFullRow(idx int, kv etcd.KeyValue, cursor string, data TableViewData,
 	return rowStyle.Render(rowContent) + "\n"
 }
 
+// fullRowWidths splits the row width between the key and value columns,
+// after the metadata columns when they are shown.
+func fullRowWidths(data TableViewData, available int) (keyColWidth, valueColWidth int) {
+	if data.ShowMeta {
+		available -= len(metaGap) + metaWidth
+	}
+	if data.Filter.HasFilterText() {
+		return calculateFilteredColumnWidths(available)
+	}
+	return calculateNormalColumnWidths(available)
+}
+
+func metaHeader() string {
+	return fmt.Sprintf("%9s %9s %7s %9s %-16s", "Created", "Modified", "Version", "Size", "Lease")
+}
+
+func metaCells(kv etcd.KeyValue) string {
+	lease := "-"
+	if kv.Lease != 0 {
+		lease = etcd.FormatLeaseID(kv.Lease)
+	}
+	return fmt.Sprintf("%9d %9d %7d %9s %-16s", kv.CreateRevision, kv.ModRevision, kv.Version, utils.FormatBytes(kv.Size), lease)
+}
+
 func calculateColumnWidths(available int) (int, int) {
 	k := utils.Max(60, int(float64(available)*0.75))
 	if available-k-4 < 20 {
@@ -305,7 +351,7 @@ func calculateNormalColumnWidths(width int) (keyColWidth, valueColWidth int) {
 	return keyColWidth, valueColWidth
 }
 
-func renderSelectedRow(cursor, key, val string, kWidth, vWidth, width int) string {
+func renderHighlightedRow(cursor, key, val string, kWidth, vWidth, width int, rowStyle lipgloss.Style) string {
 	const gap = "  "
 
 	keyDisplay := truncateString(key, kWidth)
@@ -323,19 +369,26 @@ func renderSelectedRow(cursor, key, val string, kWidth, vWidth, width int) strin
 		rowContent += strings.Repeat(" ", remaining)
 	}
 
-	return style.SelectedRow.
+	return rowStyle.
+		MaxWidth(width).
 		MaxHeight(1).
 		Render(rowContent) + "\n"
 }
 
-func getCursorIndicator(isSelected bool) string {
-	if isSelected {
+func getCursorIndicator(isSelected, isMarked bool) string {
+	switch {
+	case isSelected && isMarked:
+		return ">*"
+	case isSelected:
 		return "> "
+	case isMarked:
+		return " *"
 	}
 	return "  "
 }
 
 func padToWidth(s string, width int) string {
+	width = utils.Max(0, width)
 	currentWidth := lipgloss.Width(s)
 	if currentWidth > width {
 		return utils.Truncate(s, width)
@@ -343,18 +396,20 @@ func padToWidth(s string, width int) string {
 	return s + strings.Repeat(" ", width-currentWidth)
 }
 
-func getRowStyle(isSelected bool) lipgloss.Style {
-	if isSelected {
+func getRowStyle(isSelected, isMarked bool) lipgloss.Style {
+	switch {
+	case isSelected:
 		return style.SelectedRow
+	case isMarked:
+		return style.MarkedRow
 	}
 	return style.Row
 }
 
+// truncateString shortens s to maxLen bytes. Narrow terminals can leave
+// columns with no room at all, so a negative maxLen counts as 0.
 func truncateString(s string, maxLen int) string {
-	if len(s) > maxLen {
-		return s[:maxLen-3] + "..."
-	}
-	return s
+	return utils.Truncate(s, utils.Max(0, maxLen))
 }
 
 func RenderValueView(data ValueViewData) string {
@@ -365,6 +420,9 @@ func RenderValueView(data ValueViewData) string {
 	tableWidth := calculatePaneWidth(data.Width, data.SplitRatio, true)
 	separatorWidth := lipgloss.Width(style.Separator.Render(constants.TableSeparator))
 	valueWidth := data.Width - tableWidth - separatorWidth
+	if data.PaneWidth > 0 {
+		valueWidth = data.PaneWidth
+	}
 	valueWidth = utils.Max(constants.MinPaneWidth, valueWidth)
 
 	var b strings.Builder
@@ -373,17 +431,75 @@ func RenderValueView(data ValueViewData) string {
 	if data.IsJSON {
 		title += " " + style.Badge.Render("[JSON]")
 	}
+	if data.Lease != 0 {
+		title += " " + style.Badge.Render(leaseBadge(data.Lease, data.LeaseTTL))
+	}
 	b.WriteString(lipgloss.NewStyle().MaxWidth(valueWidth-constants.HeaderPadding).MaxHeight(1).Render(title) + "\n")
+	headerLines := 2
+	if data.ModRevision != 0 {
+		meta := utils.Truncate(metadataLine(data), valueWidth-constants.HeaderPadding)
+		b.WriteString(style.RowNumber.Render(meta) + "\n")
+		headerLines++
+	}
+	banner := data.Notice
+	if data.Change != nil {
+		banner = changeBanner(data.Change, data.Diff != "")
+	}
+	if banner != "" {
+		b.WriteString(style.Banner.Render(utils.Truncate(banner, valueWidth-constants.HeaderPadding)) + "\n")
+		headerLines++
+	}
 	b.WriteString(strings.Repeat("─", valueWidth-constants.HeaderPadding) + "\n")
 
-	availableHeight := utils.Max(1, data.Height-2)
-	lines := wrapOrSplitValue(data, valueWidth)
+	availableHeight := utils.Max(1, data.Height-headerLines)
+	if data.Diff != "" {
+		renderValueContent(&b, strings.Split(data.Diff, "\n"), data.ValueViewport, availableHeight, valueWidth, renderDiffLine)
+		return b.String()
+	}
 
-	renderValueContent(&b, lines, data.ValueViewport, availableHeight, valueWidth)
+	lines := wrapOrSplitValue(data, valueWidth)
+	renderValueContent(&b, lines, data.ValueViewport, availableHeight, valueWidth, nil)
 
 	return b.String()
 }
 
+func metadataLine(data ValueViewData) string {
+	return fmt.Sprintf("created rev %d · modified rev %d · version %d", data.CreateRevision, data.ModRevision, data.Version)
+}
+
+func changeBanner(change *etcd.WatchEvent, showingDiff bool) string {
+	what := "Changed"
+	if change.Deleted {
+		what = "Deleted"
+	}
+	diff := "v diff"
+	if showingDiff {
+		diff = "v value"
+	}
+	return fmt.Sprintf(" %s at revision %d • enter reload • %s ", what, change.Revision, diff)
+}
+
+func renderDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		return style.DiffHunk.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return style.DiffAdd.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return style.DiffDel.Render(line)
+	case strings.HasPrefix(line, "~"):
+		return style.DiffChange.Render(line)
+	}
+	return line
+}
+
+func leaseBadge(lease, ttl int64) string {
+	if ttl < 0 {
+		return fmt.Sprintf("[lease %s expired]", etcd.FormatLeaseID(lease))
+	}
+	return fmt.Sprintf("[lease %s ttl %ds]", etcd.FormatLeaseID(lease), ttl)
+}
+
 func wrapOrSplitValue(data ValueViewData, width int) []string {
 	if data.IsJSON {
 		displayValue := data.FormattedValue
@@ -410,7 +526,9 @@ func wrapOrSplitValue(data ValueViewData, width int) []string {
 	return utils.WrapText(displayValue, width-constants.ContentPadding)
 }
 
-func renderValueContent(b *strings.Builder, lines []string, viewport, availableHeight, width int) {
+// renderValueContent writes the visible window of lines. render, when set,
+// styles each line after it has been truncated to fit.
+func renderValueContent(b *strings.Builder, lines []string, viewport, availableHeight, width int, render func(string) string) {
 	if len(lines) == 0 {
 		b.WriteString("\n(empty value)\n")
 		paddingNeeded := utils.Max(0, availableHeight-1)
@@ -444,6 +562,9 @@ func renderValueContent(b *strings.Builder, lines []string, viewport, availableH
 		if len(line) > width-constants.ContentPadding {
 			line = utils.Truncate(line, width-constants.ContentPadding)
 		}
+		if render != nil {
+			line = render(line)
+		}
 		b.WriteString(line)
 		b.WriteString("\n")
 		contentLinesWritten++
@@ -1,8 +1,12 @@
 package view
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/olamilekan000/etcd-tui/internal/etcd"
 	"github.com/olamilekan000/etcd-tui/internal/tui/constants"
 	"github.com/olamilekan000/etcd-tui/internal/utils"
 )
@@ -138,3 +142,43 @@ func TestPadToWidth(t *testing.T) {
 		})
 	}
 }
+
+func TestMetaCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		kv       etcd.KeyValue
+		expected string
+	}{
+		{"no lease", etcd.KeyValue{CreateRevision: 12, ModRevision: 40, Version: 3, Size: 512}, "       12        40       3     512 B -               "},
+		{"leased", etcd.KeyValue{CreateRevision: 7, ModRevision: 7, Version: 1, Size: 2048, Lease: 0x694d7a5b3c2e1f01}, "        7         7       1   2.0 KiB 694d7a5b3c2e1f01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := metaCells(tt.kv)
+			if result != tt.expected {
+				t.Errorf("metaCells() = %q, want %q", result, tt.expected)
+			}
+			if w := lipgloss.Width(result); w != metaWidth {
+				t.Errorf("metaCells() width = %d, want %d", w, metaWidth)
+			}
+		})
+	}
+}
+
+func TestRenderFullRowWidth(t *testing.T) {
+	kv := etcd.KeyValue{Key: "/config/app/feature-flags", ValuePreview: `{"enabled":true}`, CreateRevision: 12, ModRevision: 40, Version: 3, Size: 512}
+
+	for _, width := range []int{30, 60, 80, 200} {
+		for _, state := range []struct {
+			name             string
+			selected, marked bool
+		}{{"plain", false, false}, {"selected", true, false}, {"marked", false, true}} {
+			data := TableViewData{Width: width, ShowMeta: true}
+			row := renderFullRow(0, kv, getCursorIndicator(state.selected, state.marked), data, width, state.selected, state.marked)
+			if w := lipgloss.Width(strings.TrimSuffix(row, "\n")); w > width {
+				t.Errorf("%s row at width %d is %d wide", state.name, width, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells bounds the LCS table; larger inputs fall back to replacing
+// the differing middle section wholesale.
+const maxDiffCells = 1 << 20
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// UnifiedDiff returns the line diff of a and b in unified format, with
+// context unchanged lines around each change. It returns nil when a and b
+// are equal.
+func UnifiedDiff(a, b string, context int) []string {
+	if a == b {
+		return nil
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var out []string
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// Changes closer than two contexts apart share a hunk.
+		from := Max(0, i-context)
+		last := i
+		for j := i; j < len(ops) && j-last <= 2*context; j++ {
+			if ops[j].kind != ' ' {
+				last = j
+			}
+		}
+		to := Min(len(ops), last+context+1)
+
+		out = append(out, hunkHeader(ops, from, to))
+		for _, op := range ops[from:to] {
+			out = append(out, string(op.kind)+op.text)
+		}
+		i = to
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func diffMiddle(a, b []string) []diffOp {
+	var ops []diffOp
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = Max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+func hunkHeader(ops []diffOp, from, to int) string {
+	aStart, bStart := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			aStart++
+		}
+		if op.kind != '-' {
+			bStart++
+		}
+	}
+	aLen, bLen := 0, 0
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			aLen++
+		}
+		if op.kind != '-' {
+			bLen++
+		}
+	}
+	// An empty range names the line before it, as diff -u does.
+	if aLen == 0 {
+		aStart--
+	}
+	if bLen == 0 {
+		bStart--
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, aLen, bStart, bLen)
+}
+
+// SideBySide lays the line diff of a and b out as two columns of equal
+// length. Unchanged lines start with two spaces, removed lines with "- " and
+// added lines with "+ ". A run of changes is paired up line by line, and the
+// shorter side is padded with empty lines so that both columns stay aligned.
+func SideBySide(a, b string) (left, right []string) {
+	ops := diffLines(splitLines(a), splitLines(b))
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			left = append(left, "  "+ops[i].text)
+			right = append(right, "  "+ops[i].text)
+			i++
+			continue
+		}
+
+		var removed, added []string
+		for ; i < len(ops) && ops[i].kind != ' '; i++ {
+			if ops[i].kind == '-' {
+				removed = append(removed, "- "+ops[i].text)
+			} else {
+				added = append(added, "+ "+ops[i].text)
+			}
+		}
+		for n := 0; n < Max(len(removed), len(added)); n++ {
+			left = append(left, lineAt(removed, n))
+			right = append(right, lineAt(added, n))
+		}
+	}
+	return left, right
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		context  int
+		expected []string
+	}{
+		{"equal", "a\nb", "a\nb", 3, nil},
+		{
+			"changed line",
+			"a\nb\nc", "a\nx\nc", 1,
+			[]string{"@@ -1,3 +1,3 @@", " a", "-b", "+x", " c"},
+		},
+		{
+			"added line at end",
+			"a\nb", "a\nb\nc", 1,
+			[]string{"@@ -2,1 +2,2 @@", " b", "+c"},
+		},
+		{
+			"from empty",
+			"", "a\nb", 3,
+			[]string{"@@ -0,0 +1,2 @@", "+a", "+b"},
+		},
+		{
+			"to empty",
+			"a", "", 3,
+			[]string{"@@ -1,1 +0,0 @@", "-a"},
+		},
+		{
+			"separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8", "1\nX\n3\n4\n5\n6\nY\n8", 1,
+			[]string{
+				"@@ -1,3 +1,3 @@", " 1", "-2", "+X", " 3",
+				"@@ -6,3 +6,3 @@", " 6", "-7", "+Y", " 8",
+			},
+		},
+		{
+			"nearby changes share a hunk",
+			"1\n2\n3\n4\n5", "1\nX\n3\nY\n5", 1,
+			[]string{"@@ -1,5 +1,5 @@", " 1", "-2", "+X", " 3", "-4", "+Y", " 5"},
+		},
+		{
+			"trailing newline ignored",
+			"a\n", "a", 3,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnifiedDiff(tt.a, tt.b, tt.context)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("UnifiedDiff(%q, %q) = %q, want %q", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           string
+		b           string
+		left, right []string
+	}{
+		{"equal", "a\nb", "a\nb", []string{"  a", "  b"}, []string{"  a", "  b"}},
+		{
+			"changed line",
+			"a\nb\nc", "a\nx\nc",
+			[]string{"  a", "- b", "  c"},
+			[]string{"  a", "+ x", "  c"},
+		},
+		{
+			"added lines padded on the left",
+			"a", "a\nb\nc",
+			[]string{"  a", "", ""},
+			[]string{"  a", "+ b", "+ c"},
+		},
+		{
+			"uneven change",
+			"a\nb\nc\nd", "a\nx\nd",
+			[]string{"  a", "- b", "- c", "  d"},
+			[]string{"  a", "+ x", "", "  d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := SideBySide(tt.a, tt.b)
+			if !reflect.DeepEqual(left, tt.left) || !reflect.DeepEqual(right, tt.right) {
+				t.Errorf("SideBySide(%q, %q) = %q, %q, want %q, %q", tt.a, tt.b, left, right, tt.left, tt.right)
+			}
+		})
+	}
+}
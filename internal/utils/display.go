@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -117,3 +118,17 @@ func WrapText(text string, width int) []string {
 	wrapped := lipgloss.NewStyle().Width(width).Render(text)
 	return strings.Split(wrapped, "\n")
 }
+
+// FormatBytes renders n bytes with a binary unit, e.g. "512 B" or "1.5 KiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
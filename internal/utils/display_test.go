@@ -169,3 +169,23 @@ func TestSanitizeForTUI(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{1572864, "1.5 MiB"},
+		{3 << 30, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatBytes(tt.n); result != tt.expected {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, result, tt.expected)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,12 +11,8 @@ import (
 
 func FormatJSON(value string) (string, bool) {
 	trimmed := strings.TrimSpace(value)
-	if trimmed == "" || (!strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[")) {
-		return trimmed, false
-	}
-
-	var jsonValue interface{}
-	if err := json.Unmarshal([]byte(trimmed), &jsonValue); err != nil {
+	jsonValue, ok := parseJSON(trimmed)
+	if !ok {
 		return trimmed, false
 	}
 
@@ -27,6 +24,20 @@ func FormatJSON(value string) (string, bool) {
 	return formatted, true
 }
 
+// parseJSON decodes value when it is a JSON object or array.
+func parseJSON(value string) (interface{}, bool) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || (!strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[")) {
+		return nil, false
+	}
+
+	var jsonValue interface{}
+	if err := json.Unmarshal([]byte(trimmed), &jsonValue); err != nil {
+		return nil, false
+	}
+	return jsonValue, true
+}
+
 func formatJSONWithGoJq(jsonValue interface{}) (string, error) {
 	query, err := gojq.Parse(".")
 	if err != nil {
@@ -56,3 +67,37 @@ func formatJSONWithGoJq(jsonValue interface{}) (string, error) {
 
 	return strings.Join(results, "\n"), nil
 }
+
+// JSONSyntaxError locates a JSON parse error by line and column, both
+// starting at 1.
+type JSONSyntaxError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *JSONSyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ValidateJSON returns nil when value parses as JSON, and otherwise a
+// *JSONSyntaxError pointing at the offending byte.
+func ValidateJSON(value string) error {
+	var v interface{}
+	err := json.Unmarshal([]byte(value), &v)
+	if err == nil {
+		return nil
+	}
+
+	offset := len(value)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = int(syntaxErr.Offset)
+	}
+
+	// Offset counts the bytes read up to and including the bad one.
+	before := value[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n") - 1
+	return &JSONSyntaxError{Line: line, Column: Max(column, 1), Msg: strings.TrimPrefix(err.Error(), "json: ")}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -43,3 +44,41 @@ func TestFormatJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantErr    bool
+		wantLine   int
+		wantColumn int
+	}{
+		{"valid object", `{"a": 1}`, false, 0, 0},
+		{"valid array on several lines", "[\n  1,\n  2\n]", false, 0, 0},
+		{"missing value", `{"a":}`, true, 1, 6},
+		{"error on later line", "{\n  \"a\": 1,\n  \"b\": }\n", true, 3, 8},
+		{"trailing comma", "{\n  \"a\": 1,\n}", true, 3, 1},
+		{"unexpected end", `{"a": 1`, true, 1, 7},
+		{"empty", "", true, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSON(tt.input)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateJSON(%q) = %v, want nil", tt.input, err)
+				}
+				return
+			}
+
+			var syntaxErr *JSONSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ValidateJSON(%q) = %v, want *JSONSyntaxError", tt.input, err)
+			}
+			if syntaxErr.Line != tt.wantLine || syntaxErr.Column != tt.wantColumn {
+				t.Errorf("ValidateJSON(%q) at %d:%d, want %d:%d", tt.input, syntaxErr.Line, syntaxErr.Column, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var jqIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// JSONDiff compares two JSON documents by path and returns one line per
+// difference, in jq path syntax: "+ path: value" for additions, "- path:
+// value" for removals and "~ path: old → new" for changes. Arrays are
+// compared index by index. ok is false when either side is not a JSON
+// object or array.
+func JSONDiff(a, b string) (lines []string, ok bool) {
+	av, ok := parseJSON(a)
+	if !ok {
+		return nil, false
+	}
+	bv, ok := parseJSON(b)
+	if !ok {
+		return nil, false
+	}
+	diffJSON("", av, bv, &lines)
+	return lines, true
+}
+
+func diffJSON(path string, a, b interface{}, out *[]string) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, ok := av[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+
+			for _, k := range keys {
+				va, inA := av[k]
+				vb, inB := bv[k]
+				diffMember(keyPath(path, k), va, vb, inA, inB, out)
+			}
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			for i := 0; i < Max(len(av), len(bv)); i++ {
+				var va, vb interface{}
+				if i < len(av) {
+					va = av[i]
+				}
+				if i < len(bv) {
+					vb = bv[i]
+				}
+				diffMember(indexPath(path, i), va, vb, i < len(av), i < len(bv), out)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*out = append(*out, fmt.Sprintf("~ %s: %s → %s", rootPath(path), compactJSON(a), compactJSON(b)))
+	}
+}
+
+func diffMember(path string, a, b interface{}, inA, inB bool, out *[]string) {
+	switch {
+	case !inB:
+		*out = append(*out, fmt.Sprintf("- %s: %s", path, compactJSON(a)))
+	case !inA:
+		*out = append(*out, fmt.Sprintf("+ %s: %s", path, compactJSON(b)))
+	default:
+		diffJSON(path, a, b, out)
+	}
+}
+
+func keyPath(path, key string) string {
+	if jqIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return rootPath(path) + "[" + string(quoted) + "]"
+}
+
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", rootPath(path), i)
+}
+
+func rootPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func compactJSON(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
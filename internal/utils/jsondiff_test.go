@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected []string
+		wantJSON bool
+	}{
+		{
+			name:     "equal",
+			a:        `{"a": 1, "b": [1, 2]}`,
+			b:        `{"b":[1,2],"a":1}`,
+			expected: nil,
+			wantJSON: true,
+		},
+		{
+			name: "added removed changed",
+			a:    `{"keep": true, "old": "x", "port": 80}`,
+			b:    `{"keep": true, "new": {"y": 1}, "port": 8080}`,
+			expected: []string{
+				`+ .new: {"y":1}`,
+				`- .old: "x"`,
+				`~ .port: 80 → 8080`,
+			},
+			wantJSON: true,
+		},
+		{
+			name: "nested and arrays",
+			a:    `{"spec": {"hosts": ["a", "b"], "tls": {"on": false}}}`,
+			b:    `{"spec": {"hosts": ["a", "c", "d"], "tls": {"on": true}}}`,
+			expected: []string{
+				`~ .spec.hosts[1]: "b" → "c"`,
+				`+ .spec.hosts[2]: "d"`,
+				`~ .spec.tls.on: false → true`,
+			},
+			wantJSON: true,
+		},
+		{
+			name: "quoted keys and root array",
+			a:    `[{"a-b": 1}]`,
+			b:    `[{"a-b": 2}, null]`,
+			expected: []string{
+				`~ .[0]["a-b"]: 1 → 2`,
+				`+ .[1]: null`,
+			},
+			wantJSON: true,
+		},
+		{
+			name:     "type change",
+			a:        `{"a": {"b": 1}}`,
+			b:        `{"a": [1]}`,
+			expected: []string{`~ .a: {"b":1} → [1]`},
+			wantJSON: true,
+		},
+		{
+			name:     "root type change",
+			a:        `{}`,
+			b:        `[]`,
+			expected: []string{`~ .: {} → []`},
+			wantJSON: true,
+		},
+		{name: "not json", a: `{"a": 1}`, b: `plain`, wantJSON: false},
+		{name: "scalar json", a: `1`, b: `2`, wantJSON: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, ok := JSONDiff(tt.a, tt.b)
+			if ok != tt.wantJSON {
+				t.Fatalf("JSONDiff() ok = %v, want %v", ok, tt.wantJSON)
+			}
+			if !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("JSONDiff() = %q, want %q", lines, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// KeyPrefix returns key up to and including its last "/", or "" when the key
+// has no separator.
+func KeyPrefix(key string) string {
+	idx := strings.LastIndex(key, "/")
+	if idx < 0 {
+		return ""
+	}
+	return key[:idx+1]
+}
+
+// KeyValueLine formats a key and value as a single key=value line. Values that
+// would break the line are quoted.
+func KeyValueLine(key, value string) string {
+	if strings.ContainsAny(value, "\r\n") {
+		value = strconv.Quote(value)
+	}
+	return key + "=" + value
+}
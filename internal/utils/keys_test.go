@@ -0,0 +1,49 @@
+package utils
+
+import "testing"
+
+func TestKeyPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{"empty key", "", ""},
+		{"no separator", "foo", ""},
+		{"root key", "/foo", "/"},
+		{"nested key", "/config/app/name", "/config/app/"},
+		{"trailing separator", "/config/app/", "/config/app/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := KeyPrefix(tt.key)
+			if result != tt.expected {
+				t.Errorf("KeyPrefix(%q) = %q, want %q", tt.key, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKeyValueLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		expected string
+	}{
+		{"plain value", "/a", "1", "/a=1"},
+		{"empty value", "/a", "", "/a="},
+		{"value with equals", "/a", "x=y", "/a=x=y"},
+		{"multiline value", "/a", "{\n  \"x\": 1\n}", `/a="{\n  \"x\": 1\n}"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := KeyValueLine(tt.key, tt.value)
+			if result != tt.expected {
+				t.Errorf("KeyValueLine(%q, %q) = %q, want %q", tt.key, tt.value, result, tt.expected)
+			}
+		})
+	}
+}
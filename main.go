@@ -14,6 +14,8 @@ import (
 
 var (
 	configPath string
+	readOnly   bool
+	revision   int64
 )
 
 func main() {
@@ -25,10 +27,23 @@ func main() {
 Configuration can be provided via:
   - Config file: ~/.etcd-tui/config.json (or path specified with --config)
   - Environment variables: ETCDCTL_ENDPOINTS, ETCDCTL_CACERT, etc.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if configPath != "" {
+				config.SetConfigPath(configPath)
+			}
+			if readOnly {
+				config.SetReadOnly(true)
+			}
+			if revision > 0 {
+				config.SetRevision(revision)
+			}
+		},
 		Run: runTUI,
 	}
 
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "Path to config file (default: ~/.etcd-tui/config.json)")
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Refuse every write to etcd (also settable with read_only in the config file)")
+	rootCmd.PersistentFlags().Int64Var(&revision, "rev", 0, "Read the keyspace as it was at this revision; writes are disabled")
 
 	versionCmd := &cobra.Command{
 		Use:   "version",
@@ -39,6 +54,8 @@ Configuration can be provided via:
 	}
 
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(newCloneCmd())
+	rootCmd.AddCommand(newApplyCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -47,10 +64,6 @@ Configuration can be provided via:
 }
 
 func runTUI(cmd *cobra.Command, args []string) {
-	if configPath != "" {
-		config.SetConfigPath(configPath)
-	}
-
 	p := tea.NewProgram(
 		model.New(),
 		tea.WithAltScreen(),